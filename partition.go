@@ -0,0 +1,239 @@
+package set
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// --- Partitions {{{
+
+// A partition of a set S is a set of non-empty, pairwise disjoint
+// subsets of S, its blocks, whose union is S. For example, the
+// partitions of {1, 2, 3} are
+//
+//	{{1, 2, 3}}
+//	{{1, 2}, {3}}
+//	{{1, 3}, {2}}
+//	{{1}, {2, 3}}
+//	{{1}, {2}, {3}}
+//
+// Partitions correspond exactly to equivalence relations, whose
+// equivalence classes are the blocks.
+
+// ErrNotPartition is reported when a set of sets is not a partition of
+// a set.
+var ErrNotPartition = errors.New("set: not a partition")
+
+// CheckPartition returns an error wrapping ErrNotPartition, describing
+// the first problem found, unless partition is a partition of s: a set
+// of non-empty, pairwise disjoint Interfaces whose union is s.
+func CheckPartition(s, partition Interface) error {
+	seen := make(map[Element]bool, s.Cardinality())
+
+	for _, p := range partition.Elements() {
+		block, ok := p.(Interface)
+		if !ok {
+			return fmt.Errorf("%w: %v is not a set", ErrNotPartition, p)
+		}
+
+		if block.Cardinality() == 0 {
+			return fmt.Errorf("%w: contains the empty set", ErrNotPartition)
+		}
+
+		for _, e := range block.Elements() {
+			if !s.Contains(e) {
+				return fmt.Errorf("%w: %v is not contained in the set", ErrNotPartition, e)
+			}
+
+			if seen[e] {
+				return fmt.Errorf("%w: %v belongs to more than one block", ErrNotPartition, e)
+			}
+
+			seen[e] = true
+		}
+	}
+
+	if uint(len(seen)) != s.Cardinality() {
+		return fmt.Errorf("%w: the blocks do not cover the set", ErrNotPartition)
+	}
+
+	return nil
+}
+
+// IsPartition determines whether partition is a partition of s.
+func IsPartition(s, partition Interface) bool {
+	return CheckPartition(s, partition) == nil
+}
+
+// Partitions calls fn with each partition of s, of which there are
+// Bell(|s|). Enumeration stops early if fn returns false.
+//
+// Partitions are enumerated by restricted growth strings: listing the
+// elements of s in the order of Sorted as e0, e1, ..., each partition
+// is the string a0 a1 ... of the indices of their blocks, numbered in
+// order of first appearance, so that a0 = 0 and each ai is at most one
+// more than the greatest before it. The strings, and so the partitions,
+// are enumerated in lexicographic order, beginning with the partition
+// into a single block.
+//
+// Source: Knuth, The Art of Computer Programming, Volume 4A, 7.2.1.5.
+func Partitions(s Interface, fn func(partition Interface) bool) {
+	elements := Sorted(s)
+
+	restrictedGrowth(len(elements), -1, func(a []int, blocks int) bool {
+		return fn(partitionOf(elements, a, blocks))
+	})
+}
+
+// PartitionsInto calls fn with each partition of s into exactly k
+// blocks, of which there are Stirling2(|s|, k), in the order of
+// Partitions. Enumeration stops early if fn returns false.
+func PartitionsInto(s Interface, k int, fn func(partition Interface) bool) {
+	if k < 0 {
+		return
+	}
+
+	elements := Sorted(s)
+
+	restrictedGrowth(len(elements), k, func(a []int, blocks int) bool {
+		return fn(partitionOf(elements, a, blocks))
+	})
+}
+
+// restrictedGrowth calls fn with each restricted growth string of
+// length n, and its number of blocks, in lexicographic order. If k is
+// not negative, only those with exactly k blocks are enumerated. It
+// returns false if fn did.
+func restrictedGrowth(n, k int, fn func(a []int, blocks int) bool) bool {
+	a := make([]int, n)
+
+	var grow func(i, blocks int) bool
+	grow = func(i, blocks int) bool {
+		if i == n {
+			if k >= 0 && blocks != k {
+				return true
+			}
+			return fn(a, blocks)
+		}
+
+		// join an existing block, unless every remaining element is
+		// needed to open the k-th
+		if k < 0 || n-i > k-blocks {
+			for b := 0; b < blocks; b++ {
+				a[i] = b
+				if !grow(i+1, blocks) {
+					return false
+				}
+			}
+		}
+
+		// open a new block
+		if k < 0 || blocks < k {
+			a[i] = blocks
+			if !grow(i+1, blocks+1) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return grow(0, 0)
+}
+
+// partitionOf constructs the partition of elements whose blocks are
+// given by the restricted growth string a.
+func partitionOf(elements []Element, a []int, blocks int) Interface {
+	bs := make([]Interface, blocks)
+	for b := range bs {
+		bs[b] = New()
+	}
+
+	for i, e := range elements {
+		bs[a[i]].Add(e)
+	}
+
+	partition := New()
+	for _, b := range bs {
+		partition.Add(b)
+	}
+
+	return partition
+}
+
+// --- }}}
+
+// --- Counting {{{
+
+// Bell computes the Bell number B(n), the number of partitions of a set
+// of n elements: 1, 1, 2, 5, 15, 52, 203, ...
+//
+// Bell panics if n < 0.
+//
+// Source: the Bell triangle, in which each row begins with the last
+// entry of the previous row, and each further entry is the sum of its
+// left neighbour and the entry above that neighbour,
+//
+//	https://en.wikipedia.org/wiki/Bell_triangle
+func Bell(n int) *big.Int {
+	if n < 0 {
+		panic("set: Bell: negative n")
+	}
+
+	row := []*big.Int{big.NewInt(1)}
+
+	for i := 0; i < n; i++ {
+		next := make([]*big.Int, len(row)+1)
+		next[0] = row[len(row)-1]
+
+		for j := range row {
+			next[j+1] = new(big.Int).Add(next[j], row[j])
+		}
+
+		row = next
+	}
+
+	return new(big.Int).Set(row[0])
+}
+
+// Stirling2 computes the Stirling number of the second kind S(n, k),
+// the number of partitions of a set of n elements into exactly k
+// blocks. S(n, k) = 0 if k < 0 or k > n, and the sum of S(n, k) over k
+// is Bell(n).
+//
+// Stirling2 panics if n < 0.
+//
+// Source: the recurrence S(n, k) = k S(n-1, k) + S(n-1, k-1), as the
+// n-th element is either placed in one of the k blocks of a partition
+// of the others, or alone.
+func Stirling2(n, k int) *big.Int {
+	if n < 0 {
+		panic("set: Stirling2: negative n")
+	}
+
+	if k < 0 || k > n {
+		return big.NewInt(0)
+	}
+
+	// row[j] is S(i, j), for the i-th row
+	row := make([]*big.Int, k+1)
+	row[0] = big.NewInt(1)
+	for j := 1; j <= k; j++ {
+		row[j] = big.NewInt(0)
+	}
+
+	for i := 1; i <= n; i++ {
+		// descend, so that row[j-1] is still S(i-1, j-1)
+		for j := k; j >= 1; j-- {
+			row[j].Mul(row[j], big.NewInt(int64(j)))
+			row[j].Add(row[j], row[j-1])
+		}
+
+		row[0].SetInt64(0)
+	}
+
+	return row[k]
+}
+
+// --- }}}
@@ -0,0 +1,158 @@
+package set_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- TestCheckPartition {{{
+
+func TestCheckPartition(t *testing.T) {
+	t.Parallel()
+
+	s := set.WithElements(1, 2, 3, 4, 5)
+
+	p := set.WithElements(set.WithElements(1, 2), set.WithElements(3), set.WithElements(4, 5))
+	if !set.IsPartition(s, p) {
+		t.Fatalf("%s should be a partition of %s, got %v", p, s, set.CheckPartition(s, p))
+	}
+
+	if !set.IsPartition(set.New(), set.New()) {
+		t.Fatalf("∅ should be a partition of ∅")
+	}
+
+	invalid := []set.Interface{
+		set.WithElements(set.WithElements(1, 2), set.WithElements(2, 3, 4, 5)),
+		set.WithElements(set.WithElements(1, 2), set.WithElements(3, 4)),
+		set.WithElements(set.WithElements(1, 2, 3, 4, 5), set.New()),
+		set.WithElements(set.WithElements(1, 2, 3, 4, 5, 6)),
+		set.WithElements(set.WithElements(1, 2, 3, 4), 5),
+	}
+
+	for _, p := range invalid {
+		if err := set.CheckPartition(s, p); !errors.Is(err, set.ErrNotPartition) {
+			t.Fatalf("%s should not be a partition of %s, got %v", p, s, err)
+		}
+	}
+}
+
+// --- }}}
+
+// --- TestPartitions {{{
+
+func TestPartitions(t *testing.T) {
+	t.Parallel()
+
+	for n := 0; n <= 6; n++ {
+		s := set.New()
+		for i := 0; i < n; i++ {
+			s.Add(i)
+		}
+
+		seen := make(map[string]bool)
+		set.Partitions(s, func(p set.Interface) bool {
+			if !set.IsPartition(s, p) {
+				t.Fatalf("%s should be a partition of %s, got %v", p, s, set.CheckPartition(s, p))
+			}
+
+			seen[key(p)] = true
+			return true
+		})
+
+		if count := int64(len(seen)); count != set.Bell(n).Int64() {
+			t.Fatalf("%s should have %d partitions, got %d", s, set.Bell(n), count)
+		}
+
+		for k := 0; k <= n+1; k++ {
+			count := int64(0)
+			set.PartitionsInto(s, k, func(p set.Interface) bool {
+				if p.Cardinality() != uint(k) || !seen[key(p)] {
+					t.Fatalf("%s should be a partition of %s into %d blocks", p, s, k)
+				}
+
+				count++
+				return true
+			})
+
+			if count != set.Stirling2(n, k).Int64() {
+				t.Fatalf("%s should have %d partitions into %d blocks, got %d", s, set.Stirling2(n, k), k, count)
+			}
+		}
+	}
+
+	// the first partition is a single block, and enumeration stops
+	count := 0
+	set.Partitions(set.WithElements("a", "b", "c"), func(p set.Interface) bool {
+		if count == 0 && p.Cardinality() != 1 {
+			t.Fatalf("The first partition should have a single block, got %s", p)
+		}
+
+		count++
+		return count < 2
+	})
+
+	if count != 2 {
+		t.Fatalf("Enumeration should stop after 2 partitions, got %d", count)
+	}
+}
+
+// key identifies a partition, regardless of the order of its blocks
+// and their elements
+func key(p set.Interface) string {
+	blocks := make([]string, 0, p.Cardinality())
+	for _, b := range p.Elements() {
+		blocks = append(blocks, fmt.Sprint(set.Sorted(b.(set.Interface))))
+	}
+
+	sort.Strings(blocks)
+	return strings.Join(blocks, " ")
+}
+
+// --- }}}
+
+// --- TestCounting {{{
+
+func TestCounting(t *testing.T) {
+	t.Parallel()
+
+	bell := []int64{1, 1, 2, 5, 15, 52, 203, 877, 4140}
+	for n, b := range bell {
+		if set.Bell(n).Int64() != b {
+			t.Fatalf("Bell(%d) should be %d, got %s", n, b, set.Bell(n))
+		}
+	}
+
+	if b, _ := new(big.Int).SetString("51724158235372", 10); set.Bell(20).Cmp(b) != 0 {
+		t.Fatalf("Bell(20) should be %s, got %s", b, set.Bell(20))
+	}
+
+	stirling := map[[2]int]int64{
+		{0, 0}: 1, {3, 0}: 0, {3, 4}: 0, {3, -1}: 0,
+		{4, 2}: 7, {5, 3}: 25, {10, 3}: 9330, {10, 10}: 1,
+	}
+
+	for nk, s := range stirling {
+		if set.Stirling2(nk[0], nk[1]).Int64() != s {
+			t.Fatalf("Stirling2(%d, %d) should be %d, got %s", nk[0], nk[1], s, set.Stirling2(nk[0], nk[1]))
+		}
+	}
+
+	for n := 0; n <= 25; n++ {
+		sum := new(big.Int)
+		for k := 0; k <= n; k++ {
+			sum.Add(sum, set.Stirling2(n, k))
+		}
+
+		if sum.Cmp(set.Bell(n)) != 0 {
+			t.Fatalf("The Stirling numbers S(%d, k) should sum to Bell(%d)", n, n)
+		}
+	}
+}
+
+// --- }}}
@@ -0,0 +1,309 @@
+package set
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// --- Permutations {{{
+
+// The functions of this file enumerate arrangements of elements as
+// tuples, Elements, one at a time, so that the n! permutations of a set
+// need never be held at once. Each tuple passed to fn is fresh, and may
+// be retained.
+
+// orderBy defaults less to the order of Sorted, by fmt.Sprint.
+func orderBy(less func(x, y Element) bool) func(x, y Element) bool {
+	if less != nil {
+		return less
+	}
+
+	return func(x, y Element) bool {
+		return fmt.Sprint(x) < fmt.Sprint(y)
+	}
+}
+
+// sortedBy lists the elements of s ordered by less, or by Sorted if
+// less is nil.
+func sortedBy(s Interface, less func(x, y Element) bool) Elements {
+	if less == nil {
+		return Sorted(s)
+	}
+
+	elements := s.Elements()
+	sort.SliceStable(elements, func(i, j int) bool {
+		return less(elements[i], elements[j])
+	})
+
+	return elements
+}
+
+// Permutations calls fn with each permutation of the elements of s, of
+// which there are |s|!. Enumeration stops early if fn returns false.
+//
+// Successive permutations differ by a single swap, but are in no
+// particular order; see LexicographicPermutations.
+//
+// Source: Heap's algorithm,
+//
+//	https://en.wikipedia.org/wiki/Heap%27s_algorithm
+func Permutations(s Interface, fn func(Elements) bool) {
+	a := Sorted(s)
+	n := len(a)
+
+	if !fn(append(Elements(nil), a...)) {
+		return
+	}
+
+	// c[i] counts the swaps made at level i
+	c := make([]int, n)
+
+	for i := 1; i < n; {
+		if c[i] == i {
+			c[i] = 0
+			i++
+			continue
+		}
+
+		if i%2 == 0 {
+			a[0], a[i] = a[i], a[0]
+		} else {
+			a[c[i]], a[i] = a[i], a[c[i]]
+		}
+
+		if !fn(append(Elements(nil), a...)) {
+			return
+		}
+
+		c[i]++
+		i = 1
+	}
+}
+
+// LexicographicPermutations calls fn with each permutation of the
+// elements of s in lexicographic order under less, beginning with the
+// elements sorted by less, and ending with them reversed. If less is
+// nil, elements are compared by fmt.Sprint, as by Sorted. Enumeration
+// stops early if fn returns false.
+//
+// less must be a strict total order on the elements of s.
+//
+// Source: Narayana Pandita's algorithm,
+//
+//	https://en.wikipedia.org/wiki/Permutation#Generation_in_lexicographic_order
+func LexicographicPermutations(s Interface, less func(x, y Element) bool, fn func(Elements) bool) {
+	a := sortedBy(s, less)
+	less = orderBy(less)
+
+	for {
+		if !fn(append(Elements(nil), a...)) {
+			return
+		}
+
+		// the last ascent, a[i] < a[i+1]
+		i := len(a) - 2
+		for i >= 0 && !less(a[i], a[i+1]) {
+			i--
+		}
+
+		if i < 0 {
+			return
+		}
+
+		// the last element greater than a[i]
+		j := len(a) - 1
+		for !less(a[i], a[j]) {
+			j--
+		}
+
+		a[i], a[j] = a[j], a[i]
+
+		for l, r := i+1, len(a)-1; l < r; l, r = l+1, r-1 {
+			a[l], a[r] = a[r], a[l]
+		}
+	}
+}
+
+// KPermutations calls fn with each arrangement of k distinct elements
+// of s, of which there are |s|!/(|s|-k)!, in lexicographic order by
+// Sorted. Enumeration stops early if fn returns false. There are no
+// arrangements unless 0 ≤ k ≤ |s|.
+func KPermutations(s Interface, k int, fn func(Elements) bool) {
+	elements := Sorted(s)
+	if k < 0 || k > len(elements) {
+		return
+	}
+
+	arrangement := make(Elements, k)
+	used := make([]bool, len(elements))
+
+	var arrange func(i int) bool
+	arrange = func(i int) bool {
+		if i == k {
+			return fn(append(Elements(nil), arrangement...))
+		}
+
+		for j, e := range elements {
+			if used[j] {
+				continue
+			}
+
+			used[j] = true
+			arrangement[i] = e
+			ok := arrange(i + 1)
+			used[j] = false
+
+			if !ok {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	arrange(0)
+}
+
+// Derangements calls fn with each derangement of tuple: each
+// permutation of its elements which leaves none in its original
+// position. There are Subfactorial(len(tuple)) if the elements are
+// distinct. Derangements are enumerated in lexicographic order of
+// positions in tuple. Enumeration stops early if fn returns false.
+func Derangements(tuple Elements, fn func(Elements) bool) {
+	n := len(tuple)
+
+	arrangement := make(Elements, n)
+	used := make([]bool, n)
+
+	var arrange func(i int) bool
+	arrange = func(i int) bool {
+		if i == n {
+			return fn(append(Elements(nil), arrangement...))
+		}
+
+		for j, e := range tuple {
+			if used[j] || j == i {
+				continue
+			}
+
+			used[j] = true
+			arrangement[i] = e
+			ok := arrange(i + 1)
+			used[j] = false
+
+			if !ok {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	arrange(0)
+}
+
+// --- }}}
+
+// --- Counting and Ranking {{{
+
+// Factorial computes n! = 1 × 2 × ... × n, the number of permutations
+// of n elements.
+//
+// Factorial panics if n < 0.
+func Factorial(n int) *big.Int {
+	if n < 0 {
+		panic("set: Factorial: negative n")
+	}
+
+	return new(big.Int).MulRange(1, int64(n))
+}
+
+// Subfactorial computes !n, the number of derangements of n elements:
+// 1, 0, 1, 2, 9, 44, 265, ...
+//
+// Subfactorial panics if n < 0.
+//
+// Source: the recurrence !n = (n-1)(!(n-1) + !(n-2)).
+func Subfactorial(n int) *big.Int {
+	if n < 0 {
+		panic("set: Subfactorial: negative n")
+	}
+
+	previous, current := big.NewInt(1), big.NewInt(0)
+	if n == 0 {
+		return previous
+	}
+
+	for i := 2; i <= n; i++ {
+		next := new(big.Int).Add(previous, current)
+		next.Mul(next, big.NewInt(int64(i-1)))
+		previous, current = current, next
+	}
+
+	return current
+}
+
+// Rank computes the position, from 0, of the permutation tuple among
+// the permutations of its elements in the order of
+// LexicographicPermutations under less. If less is nil, elements are
+// compared by fmt.Sprint. The elements of tuple must be distinct.
+//
+// Source: the Lehmer code of the permutation, whose i-th digit is the
+// number of later elements less than the i-th, read in the factorial
+// number system.
+func Rank(tuple Elements, less func(x, y Element) bool) *big.Int {
+	less = orderBy(less)
+	n := len(tuple)
+
+	rank := new(big.Int)
+	for i, x := range tuple {
+		smaller := 0
+		for _, y := range tuple[i+1:] {
+			if less(y, x) {
+				smaller++
+			}
+		}
+
+		rank.Mul(rank, big.NewInt(int64(n-i)))
+		rank.Add(rank, big.NewInt(int64(smaller)))
+	}
+
+	return rank
+}
+
+// Unrank constructs the permutation of the elements of s at position
+// rank, from 0, in the order of LexicographicPermutations under less,
+// the inverse of Rank. If less is nil, elements are compared by
+// fmt.Sprint.
+//
+// Unrank panics unless 0 ≤ rank < |s|!.
+func Unrank(s Interface, less func(x, y Element) bool, rank *big.Int) Elements {
+	remaining := sortedBy(s, less)
+	n := len(remaining)
+
+	if rank.Sign() < 0 || rank.Cmp(Factorial(n)) >= 0 {
+		panic("set: Unrank: rank out of range")
+	}
+
+	// the digits of rank in the factorial number system, least
+	// significant first
+	digits := make([]int, n)
+	r := new(big.Int).Set(rank)
+	digit := new(big.Int)
+	for i := 1; i <= n; i++ {
+		r.DivMod(r, big.NewInt(int64(i)), digit)
+		digits[i-1] = int(digit.Int64())
+	}
+
+	tuple := make(Elements, 0, n)
+	for i := n - 1; i >= 0; i-- {
+		d := digits[i]
+		tuple = append(tuple, remaining[d])
+		remaining = append(remaining[:d], remaining[d+1:]...)
+	}
+
+	return tuple
+}
+
+// --- }}}
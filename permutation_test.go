@@ -0,0 +1,174 @@
+package set_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- TestPermutations {{{
+
+func TestPermutations(t *testing.T) {
+	t.Parallel()
+
+	for n := 0; n <= 6; n++ {
+		s := set.New()
+		for i := 0; i < n; i++ {
+			s.Add(i)
+		}
+
+		seen := make(map[string]bool)
+		set.Permutations(s, func(p set.Elements) bool {
+			if len(p) != n || !set.Equivalent(set.With(p), s) {
+				t.Fatalf("%v should be a permutation of %s", p, s)
+			}
+
+			seen[fmt.Sprint(p)] = true
+			return true
+		})
+
+		if count := int64(len(seen)); count != set.Factorial(n).Int64() {
+			t.Fatalf("%s should have %s permutations, got %d", s, set.Factorial(n), count)
+		}
+	}
+
+	// each tuple is fresh
+	var kept []set.Elements
+	set.Permutations(set.WithElements("a", "b", "c"), func(p set.Elements) bool {
+		kept = append(kept, p)
+		return len(kept) < 2
+	})
+
+	if len(kept) != 2 || fmt.Sprint(kept[0]) == fmt.Sprint(kept[1]) {
+		t.Fatalf("Enumeration should stop after 2 distinct permutations, got %v", kept)
+	}
+}
+
+// --- }}}
+
+// --- TestLexicographicPermutations {{{
+
+func TestLexicographicPermutations(t *testing.T) {
+	t.Parallel()
+
+	s := set.WithElements(3, 1, 4, 2)
+	descending := func(x, y set.Element) bool { return x.(int) > y.(int) }
+
+	var ps []set.Elements
+	set.LexicographicPermutations(s, descending, func(p set.Elements) bool {
+		ps = append(ps, p)
+		return true
+	})
+
+	if len(ps) != 24 {
+		t.Fatalf("%s should have 24 permutations, got %d", s, len(ps))
+	}
+
+	if fmt.Sprint(ps[0]) != "[4 3 2 1]" || fmt.Sprint(ps[23]) != "[1 2 3 4]" {
+		t.Fatalf("Permutations should run from [4 3 2 1] to [1 2 3 4], got %v to %v", ps[0], ps[23])
+	}
+
+	for i := range ps {
+		if rank := set.Rank(ps[i], descending); rank.Int64() != int64(i) {
+			t.Fatalf("Rank(%v) should be %d, got %s", ps[i], i, rank)
+		}
+
+		if p := set.Unrank(s, descending, big.NewInt(int64(i))); fmt.Sprint(p) != fmt.Sprint(ps[i]) {
+			t.Fatalf("Unrank(%d) should be %v, got %v", i, ps[i], p)
+		}
+	}
+
+	// with the default order, by fmt.Sprint
+	words := set.WithElements("b", "a", "c")
+	var first set.Elements
+	set.LexicographicPermutations(words, nil, func(p set.Elements) bool {
+		first = p
+		return false
+	})
+
+	if fmt.Sprint(first) != "[a b c]" {
+		t.Fatalf("The first permutation should be [a b c], got %v", first)
+	}
+
+	if rank := set.Rank(set.Elements{"c", "b", "a"}, nil); rank.Int64() != 5 {
+		t.Fatalf("Rank([c b a]) should be 5, got %s", rank)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Unrank out of range should panic")
+		}
+	}()
+
+	set.Unrank(words, nil, big.NewInt(6))
+}
+
+// --- }}}
+
+// --- TestKPermutations {{{
+
+func TestKPermutations(t *testing.T) {
+	t.Parallel()
+
+	s := set.WithElements("a", "b", "c", "d")
+
+	for k, expected := range []int{1, 4, 12, 24, 24, 0} {
+		var ps []set.Elements
+		set.KPermutations(s, k, func(p set.Elements) bool {
+			if len(p) != k || set.With(p).Cardinality() != uint(k) || !set.IsSubset(set.With(p), s) {
+				t.Fatalf("%v should be %d distinct elements of %s", p, k, s)
+			}
+
+			ps = append(ps, p)
+			return true
+		})
+
+		if len(ps) != expected {
+			t.Fatalf("%s should have %d arrangements of %d, got %d", s, expected, k, len(ps))
+		}
+
+		if k == 2 && (fmt.Sprint(ps[0]) != "[a b]" || fmt.Sprint(ps[11]) != "[d c]") {
+			t.Fatalf("Arrangements of 2 should run from [a b] to [d c], got %v", ps)
+		}
+	}
+}
+
+// --- }}}
+
+// --- TestDerangements {{{
+
+func TestDerangements(t *testing.T) {
+	t.Parallel()
+
+	subfactorial := []int64{1, 0, 1, 2, 9, 44, 265, 1854}
+	for n, d := range subfactorial {
+		if set.Subfactorial(n).Int64() != d {
+			t.Fatalf("Subfactorial(%d) should be %d, got %s", n, d, set.Subfactorial(n))
+		}
+
+		tuple := make(set.Elements, n)
+		for i := range tuple {
+			tuple[i] = i
+		}
+
+		count := int64(0)
+		set.Derangements(tuple, func(p set.Elements) bool {
+			for i, e := range p {
+				if e == tuple[i] {
+					t.Fatalf("%v should leave no element in place", p)
+				}
+			}
+
+			count++
+			return true
+		})
+
+		if count != d {
+			t.Fatalf("%v should have %d derangements, got %d", tuple, d, count)
+		}
+	}
+}
+
+// --- }}}
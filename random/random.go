@@ -0,0 +1,178 @@
+/*
+Package random generates random sets and relations, for example as
+inputs to property based tests.
+
+Every generator draws from a *rand.Rand given by the caller, and
+visits elements in the order of set.Sorted, so that a generator given
+a source with the same seed constructs the same result:
+
+	rng := rand.New(rand.NewSource(42))
+	order := random.PartialOrder(rng, universe, 0.3)
+
+The guarantee holds only for universes whose elements set.Sorted can
+tell apart, by fmt.Sprint and then by type. The order of elements alike
+in both, such as distinct pointers to equal values, follows the
+iteration order of the set, so results over them may differ from run to
+run despite the seed.
+*/
+package random
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+// --- Sets {{{
+
+// Subset constructs a subset of universe containing each element
+// independently with probability p.
+func Subset(rng *rand.Rand, universe set.Interface, p float64) set.Interface {
+	s := set.New()
+
+	for _, e := range set.Sorted(universe) {
+		if rng.Float64() < p {
+			s.Add(e)
+		}
+	}
+
+	return s
+}
+
+// SubsetOfSize constructs a subset of universe with k elements, each
+// such subset being equally likely.
+//
+// SubsetOfSize panics unless 0 ≤ k ≤ |universe|.
+func SubsetOfSize(rng *rand.Rand, universe set.Interface, k int) set.Interface {
+	return set.With(set.Sample(rng, universe, k))
+}
+
+// --- }}}
+
+// --- Relations {{{
+
+// Relation constructs a relation over universe containing each pair
+// (x, y) independently with probability density.
+func Relation(rng *rand.Rand, universe set.Interface, density float64) relation.Interface {
+	elements := set.Sorted(universe)
+	b := relation.New(universe)
+
+	for _, x := range elements {
+		for _, y := range elements {
+			if rng.Float64() < density {
+				b.AddRelation(x, y)
+			}
+		}
+	}
+
+	return b
+}
+
+// Equivalence constructs an equivalence relation over universe with at
+// most classes equivalence classes, by assigning each element to one
+// of them uniformly at random.
+//
+// Equivalence panics if classes < 1 and universe is not empty.
+func Equivalence(rng *rand.Rand, universe set.Interface, classes int) relation.Interface {
+	elements := set.Sorted(universe)
+	if classes < 1 && len(elements) > 0 {
+		panic("random: Equivalence: fewer than one class")
+	}
+
+	class := make(map[set.Element]int, len(elements))
+	for _, x := range elements {
+		class[x] = rng.Intn(classes)
+	}
+
+	return relation.Materialize(relation.NewFunctionBinaryRelation(universe, func(x, y set.Element) bool {
+		return class[x] == class[y]
+	}))
+}
+
+// TotalOrder constructs a (reflexive) total order over universe, each
+// of the |universe|! such orders being equally likely.
+func TotalOrder(rng *rand.Rand, universe set.Interface) relation.Interface {
+	elements := set.Shuffle(rng, universe)
+	b := relation.New(universe)
+
+	for i, x := range elements {
+		for _, y := range elements[i:] {
+			b.AddRelation(x, y)
+		}
+	}
+
+	return b
+}
+
+// PartialOrder constructs a (reflexive) partial order over universe.
+// The elements are shuffled, each pair (x, y) with x before y is
+// related with probability density, and the result is closed under
+// reflexivity and transitivity. A density of 0 yields the identity,
+// and of 1 a total order.
+func PartialOrder(rng *rand.Rand, universe set.Interface, density float64) relation.Interface {
+	elements := set.Shuffle(rng, universe)
+	b := relation.New(universe)
+
+	for i, x := range elements {
+		for _, y := range elements[i+1:] {
+			if rng.Float64() < density {
+				b.AddRelation(x, y)
+			}
+		}
+	}
+
+	return relation.ReflexiveTransitiveClosure(b)
+}
+
+// WeakOrder constructs a weak order over universe with at most levels
+// indifference classes: each element is assigned one of levels
+// utilities uniformly at random, and xBy iff the utility of x is at
+// least that of y.
+//
+// WeakOrder panics if levels < 1 and universe is not empty.
+func WeakOrder(rng *rand.Rand, universe set.Interface, levels int) relation.Interface {
+	elements := set.Sorted(universe)
+	if levels < 1 && len(elements) > 0 {
+		panic("random: WeakOrder: fewer than one level")
+	}
+
+	utility := make(map[set.Element]float64, len(elements))
+	for _, x := range elements {
+		utility[x] = float64(rng.Intn(levels))
+	}
+
+	return relation.Materialize(relation.FromUtility(universe, func(x set.Element) float64 {
+		return utility[x]
+	}))
+}
+
+// --- }}}
+
+// --- Functions {{{
+
+// ErrNoFunction is returned by Function when there is no function from
+// the domain to the codomain: when the domain is not empty, but the
+// codomain is.
+var ErrNoFunction = errors.New("random: no function from a non-empty domain to the empty codomain")
+
+// Function constructs a function from domain to codomain which maps
+// each element of the domain to an element of the codomain uniformly
+// at random, each of the |codomain|^|domain| functions being equally
+// likely.
+func Function(rng *rand.Rand, domain, codomain set.Interface) (relation.Function, error) {
+	xs, ys := set.Sorted(domain), set.Sorted(codomain)
+	if len(ys) == 0 && len(xs) > 0 {
+		return nil, ErrNoFunction
+	}
+
+	mapping := make(map[set.Element]set.Element, len(xs))
+	for _, x := range xs {
+		mapping[x] = ys[rng.Intn(len(ys))]
+	}
+
+	return relation.NewFunction(domain, codomain, mapping)
+}
+
+// --- }}}
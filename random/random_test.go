@@ -0,0 +1,173 @@
+package random_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/random"
+	"github.com/nlandolfi/set/relation"
+)
+
+// universe constructs {0, ..., n-1}
+func universe(n int) set.Interface {
+	s := set.New()
+	for i := 0; i < n; i++ {
+		s.Add(i)
+	}
+	return s
+}
+
+// equal reports whether r and s contain the same pairs over the universe of r.
+func equal(r, s relation.AbstractInterface) bool {
+	for _, x := range r.Universe().Elements() {
+		for _, y := range r.Universe().Elements() {
+			if r.ContainsRelation(x, y) != s.ContainsRelation(x, y) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func TestSubset(t *testing.T) {
+	u := universe(100)
+	rng := rand.New(rand.NewSource(1))
+
+	if s := random.Subset(rng, u, 0); s.Cardinality() != 0 {
+		t.Errorf("Expected a subset of probability 0 to be empty, got %s", s)
+	}
+
+	if s := random.Subset(rng, u, 1); !set.Equivalent(s, u) {
+		t.Errorf("Expected a subset of probability 1 to be the universe, got %s", s)
+	}
+
+	s := random.Subset(rng, u, 0.5)
+	if !set.IsSubset(s, u) || s.Cardinality() < 25 || s.Cardinality() > 75 {
+		t.Errorf("Expected about half of the universe, got %s", s)
+	}
+
+	for k := 0; k <= 10; k++ {
+		if s := random.SubsetOfSize(rng, u, k); !set.IsSubset(s, u) || s.Cardinality() != uint(k) {
+			t.Errorf("Expected a subset of %d elements, got %s", k, s)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a subset larger than the universe to panic")
+		}
+	}()
+
+	random.SubsetOfSize(rng, u, 101)
+}
+
+func TestReproducible(t *testing.T) {
+	u := universe(20)
+
+	generators := map[string]func(*rand.Rand) relation.AbstractInterface{
+		"Relation":     func(rng *rand.Rand) relation.AbstractInterface { return random.Relation(rng, u, 0.3) },
+		"Equivalence":  func(rng *rand.Rand) relation.AbstractInterface { return random.Equivalence(rng, u, 4) },
+		"TotalOrder":   func(rng *rand.Rand) relation.AbstractInterface { return random.TotalOrder(rng, u) },
+		"PartialOrder": func(rng *rand.Rand) relation.AbstractInterface { return random.PartialOrder(rng, u, 0.2) },
+		"WeakOrder":    func(rng *rand.Rand) relation.AbstractInterface { return random.WeakOrder(rng, u, 5) },
+	}
+
+	for name, generate := range generators {
+		r := generate(rand.New(rand.NewSource(7)))
+		s := generate(rand.New(rand.NewSource(7)))
+
+		if !equal(r, s) {
+			t.Errorf("%s: Expected the same seed to generate the same relation", name)
+		}
+
+		if !set.Equivalent(r.Universe(), u) {
+			t.Errorf("%s: Expected the relation to be over the universe, got %s", name, r.Universe())
+		}
+	}
+
+	r := random.Subset(rand.New(rand.NewSource(7)), u, 0.5)
+	s := random.Subset(rand.New(rand.NewSource(7)), set.Clone(u), 0.5)
+
+	if !set.Equivalent(r, s) {
+		t.Errorf("Expected the same seed to generate the same subset, got %s and %s", r, s)
+	}
+}
+
+func TestRelation(t *testing.T) {
+	u := universe(30)
+	rng := rand.New(rand.NewSource(2))
+
+	if b := random.Relation(rng, u, 0); relation.Size(b) != 0 {
+		t.Errorf("Expected a relation of density 0 to be empty, got %d pairs", relation.Size(b))
+	}
+
+	if b := random.Relation(rng, u, 1); relation.Size(b) != 900 {
+		t.Errorf("Expected a relation of density 1 to be full, got %d pairs", relation.Size(b))
+	}
+
+	if b := random.Relation(rng, u, 0.5); relation.Size(b) < 350 || relation.Size(b) > 550 {
+		t.Errorf("Expected about half of the pairs, got %d", relation.Size(b))
+	}
+}
+
+func TestOrders(t *testing.T) {
+	u := universe(25)
+	rng := rand.New(rand.NewSource(3))
+
+	for i := 0; i < 10; i++ {
+		if b := random.Equivalence(rng, u, 3); !relation.Equivalence(b) {
+			t.Errorf("Expected an equivalence relation, got %v", relation.EquivalenceViolation(b))
+		} else if classes, _ := relation.EquivalenceClasses(b); classes.Cardinality() > 3 {
+			t.Errorf("Expected at most 3 classes, got %d", classes.Cardinality())
+		}
+
+		if b := random.TotalOrder(rng, u); !relation.PartialOrder(b) || !relation.Complete(b) {
+			t.Errorf("Expected a total order")
+		}
+
+		if b := random.PartialOrder(rng, u, 0.1); !relation.PartialOrder(b) {
+			t.Errorf("Expected a partial order, got %v", relation.PartialOrderViolation(b))
+		}
+
+		if b := random.WeakOrder(rng, u, 4); !relation.WeakOrder(b) {
+			t.Errorf("Expected a weak order, got %v", relation.WeakOrderViolation(b))
+		} else if classes, _ := relation.IndifferenceClasses(b); len(classes) > 4 {
+			t.Errorf("Expected at most 4 indifference classes, got %d", len(classes))
+		}
+	}
+
+	if b := random.PartialOrder(rng, u, 0); !equal(b, relation.ReflexiveClosure(relation.New(u))) {
+		t.Errorf("Expected a partial order of density 0 to be the identity")
+	}
+
+	if b := random.PartialOrder(rng, u, 1); !relation.Complete(b) {
+		t.Errorf("Expected a partial order of density 1 to be total")
+	}
+}
+
+func TestFunction(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	domain, codomain := universe(10), set.WithElements("a", "b", "c")
+
+	f, err := random.Function(rng, domain, codomain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, x := range domain.Elements() {
+		if !codomain.Contains(f.Apply(x)) {
+			t.Errorf("Expected f(%v) to be in the codomain, got %v", x, f.Apply(x))
+		}
+	}
+
+	if _, err := random.Function(rng, domain, set.New()); !errors.Is(err, random.ErrNoFunction) {
+		t.Errorf("Expected ErrNoFunction, got %v", err)
+	}
+
+	if f, err := random.Function(rng, set.New(), set.New()); err != nil || f.Domain().Cardinality() != 0 {
+		t.Errorf("Expected the empty function, got %v", err)
+	}
+}
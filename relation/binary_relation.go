@@ -1,6 +1,10 @@
 package relation
 
-import "github.com/nlandolfi/set"
+import (
+	"fmt"
+
+	"github.com/nlandolfi/set"
+)
 
 // --- Types {{{
 
@@ -23,6 +27,37 @@ type (
 		AddRelation(set.Element, set.Element)
 		RemoveRelation(set.Element, set.Element)
 	}
+
+	// An Enumerable relation lists its pairs from its representation,
+	// rather than by testing each pair of its universe. The relations
+	// constructed by New and NewDense are Enumerable.
+	//
+	// Enumerable is optional: the functions of this package which
+	// accept an AbstractInterface, such as EachPair, Size, Successors
+	// and Predecessors, use these methods if they are available, and
+	// otherwise fall back to ContainsRelation.
+	Enumerable interface {
+		AbstractInterface
+
+		// Pairs retrieves a slice of all related pairs, each as a
+		// set.Tuple (x, y) such that xBy.
+		//
+		// Note: Mutating this slice must not modify the relation.
+		Pairs() []set.Tuple
+
+		// Size retrieves the number of related pairs, |B|.
+		Size() uint
+
+		// Successors constructs the set of elements to which x is
+		// related, {y ∈ X | xBy}. It panics with a *UniverseError if
+		// x is not contained in the universe.
+		Successors(x set.Element) set.Interface
+
+		// Predecessors constructs the set of elements which are
+		// related to y, {x ∈ X | xBy}. It panics with a *UniverseError
+		// if y is not contained in the universe.
+		Predecessors(y set.Element) set.Interface
+	}
 )
 
 /// --- }}}
@@ -52,19 +87,15 @@ func (b *binaryRelation) Universe() set.Interface {
 	return b.universe
 }
 
-// assert is a helper function to provide
-// moderate runtime type checking on the Element interface
-func assert(flag bool, s string) {
-	if !flag {
-		panic(s)
-	}
-}
-
 // AddRelation will note the fact that e1 is related to e2
 // Denote our binary relation as B, then e1 B e2 <=> AddRelation(e1, e2)
+//
+// AddRelation panics with a *UniverseError if either element is not
+// contained in the universe; see TryAddRelation.
 func (b *binaryRelation) AddRelation(e1, e2 set.Element) {
-	assert(b.universe.Contains(e1), "(*binaryRelation).AddRelation: element 1 is not contained in universe")
-	assert(b.universe.Contains(e2), "(*binaryRelation).AddRelation: element 2 is not contained in universe")
+	if err := checkPair("AddRelation", b.universe, e1, e2); err != nil {
+		panic(err)
+	}
 
 	var bucket map[set.Element]bool
 	var exists bool
@@ -83,9 +114,13 @@ func (b *binaryRelation) AddRelation(e1, e2 set.Element) {
 
 // RemoveRelation is the inverse operation of AddRelation
 // It works regardless of whether the relation is actually present
+//
+// RemoveRelation panics with a *UniverseError if either element is not
+// contained in the universe; see TryRemoveRelation.
 func (b *binaryRelation) RemoveRelation(e1, e2 set.Element) {
-	assert(b.universe.Contains(e1), "(*binaryRelation).AddRelation: element 1 is not contained in universe")
-	assert(b.universe.Contains(e2), "(*binaryRelation).AddRelation: element 2 is not contained in universe")
+	if err := checkPair("RemoveRelation", b.universe, e1, e2); err != nil {
+		panic(err)
+	}
 
 	if bucket, exists := b.relations[e1]; exists {
 		if _, exists := bucket[e2]; exists {
@@ -97,9 +132,13 @@ func (b *binaryRelation) RemoveRelation(e1, e2 set.Element) {
 // ContainsRelation determines whether the given relation exists and is
 // defined as a member of this binary relation. Note: Order of e1, and e2
 // matters, of course.
+//
+// ContainsRelation panics with a *UniverseError if either element is not
+// contained in the universe; see TryContainsRelation.
 func (b *binaryRelation) ContainsRelation(e1, e2 set.Element) bool {
-	assert(b.universe.Contains(e1), "(*binaryRelation).AddRelation: element 1 is not contained in universe")
-	assert(b.universe.Contains(e2), "(*binaryRelation).AddRelation: element 2 is not contained in universe")
+	if err := checkPair("ContainsRelation", b.universe, e1, e2); err != nil {
+		panic(err)
+	}
 
 	if bucket, exists := b.relations[e1]; exists {
 		if _, defined := bucket[e2]; defined {
@@ -110,6 +149,62 @@ func (b *binaryRelation) ContainsRelation(e1, e2 set.Element) bool {
 	return false
 }
 
+// Pairs retrieves a slice of all related pairs.
+func (b *binaryRelation) Pairs() []set.Tuple {
+	pairs := make([]set.Tuple, 0, b.Size())
+
+	for e1, bucket := range b.relations {
+		for e2 := range bucket {
+			pairs = append(pairs, set.Tuple{First: e1, Second: e2})
+		}
+	}
+
+	return pairs
+}
+
+// Size retrieves the number of related pairs.
+func (b *binaryRelation) Size() uint {
+	size := 0
+	for _, bucket := range b.relations {
+		size += len(bucket)
+	}
+	return uint(size)
+}
+
+// Successors constructs the set of elements to which e is related.
+//
+// Successors panics with a *UniverseError if e is not contained in the
+// universe.
+func (b *binaryRelation) Successors(e set.Element) set.Interface {
+	if err := checkPair("Successors", b.universe, e, e); err != nil {
+		panic(err)
+	}
+
+	s := set.New()
+	for e2 := range b.relations[e] {
+		s.Add(e2)
+	}
+	return s
+}
+
+// Predecessors constructs the set of elements which are related to e.
+//
+// Predecessors panics with a *UniverseError if e is not contained in
+// the universe.
+func (b *binaryRelation) Predecessors(e set.Element) set.Interface {
+	if err := checkPair("Predecessors", b.universe, e, e); err != nil {
+		panic(err)
+	}
+
+	s := set.New()
+	for e1, bucket := range b.relations {
+		if bucket[e] {
+			s.Add(e1)
+		}
+	}
+	return s
+}
+
 // --- }}}
 
 // --- Properties {{{
@@ -117,90 +212,235 @@ func (b *binaryRelation) ContainsRelation(e1, e2 set.Element) bool {
 // Reflexive checks the following condition:
 //  xBx for any x ∈ X ≡ Universe()
 func Reflexive(b AbstractInterface) bool {
+	return ReflexiveViolation(b) == nil
+}
+
+// ReflexiveViolation returns an element x such that not xBx,
+// or nil if b is Reflexive.
+func ReflexiveViolation(b AbstractInterface) *Violation {
 	for _, e := range b.Universe().Elements() {
 		if !b.ContainsRelation(e, e) {
-			return false
+			return violate("reflexive", fmt.Sprintf("(%v, %v) ∉ B", e, e), e)
 		}
 	}
 
-	return true
+	return nil
+}
+
+// reflexiveViolation is ReflexiveViolation of the snapshot g.
+func (g *graph) reflexiveViolation() *Violation {
+	for x, e := range g.elems {
+		if !g.rows[x].has(x) {
+			return violate("reflexive", fmt.Sprintf("(%v, %v) ∉ B", e, e), e)
+		}
+	}
+
+	return nil
 }
 
 // Complete checks the following condition:
 //  xBy or yBx for any x, y ∈ X ≡ Universe()
 func Complete(b AbstractInterface) bool {
-	elems := b.Universe().Elements()
-
-	// n^2! yuck!
-	for _, x := range elems {
-		for _, y := range elems {
-			if !(b.ContainsRelation(x, y) || b.ContainsRelation(y, x)) {
-				return false
-			}
+	return CompleteViolation(b) == nil
+}
+
+// CompleteViolation returns a pair (x, y) such that neither xBy
+// nor yBx, or nil if b is Complete.
+func CompleteViolation(b AbstractInterface) *Violation {
+	return graphOf(b).completeViolation()
+}
+
+// completeViolation is CompleteViolation of the snapshot g.
+func (g *graph) completeViolation() *Violation {
+	cols, all := g.columns(), g.all()
+
+	for x := range g.elems {
+		if v := g.incomparable(x, cols, all); v != nil {
+			return v
 		}
 	}
 
-	return true
+	return nil
+}
+
+// all constructs the bitset of every vertex of g.
+func (g *graph) all() bitset {
+	all := newBitset(len(g.elems))
+	for i := range g.elems {
+		all.add(i)
+	}
+	return all
+}
+
+// incomparable returns a pair (x, y) such that neither xBy nor yBx,
+// or nil if x is comparable to every y; cols and all are the columns
+// of g and the set of its vertices.
+func (g *graph) incomparable(x int, cols []bitset, all bitset) *Violation {
+	// x is comparable to every y when the union of its row and
+	// column is everything
+	related := g.rows[x].clone()
+	related.union(cols[x])
+
+	if y := all.firstMissing(related); y >= 0 {
+		return g.incomparablePair(x, y)
+	}
+
+	return nil
+}
+
+// incomparablePair returns the pair (x, y) if neither xBy nor yBx, or
+// nil otherwise. It reads only the rows of x and y.
+func (g *graph) incomparablePair(x, y int) *Violation {
+	if g.rows[x].has(y) || g.rows[y].has(x) {
+		return nil
+	}
+
+	ex, ey := g.elems[x], g.elems[y]
+	return violate("complete", fmt.Sprintf("neither (%v, %v) nor (%v, %v) ∈ B", ex, ey, ey, ex), ex, ey)
 }
 
 // Transitive checks the following condition:
 //	 (xBy and yBz) ⇒  xBz for any x, y, z ∈ X ≡ Universe()
+//
+// Transitivity alone says nothing of completeness, see WeakOrder.
 func Transitive(b AbstractInterface) bool {
-	if !Complete(b) {
-		return false
-	}
-
-	elems := b.Universe().Elements()
-
-	// n^3 :(
-	for _, x := range elems {
-		for _, y := range elems {
-			for _, z := range elems {
-				if b.ContainsRelation(x, y) && b.ContainsRelation(y, z) {
-					if !b.ContainsRelation(x, z) {
-						return false
-					}
-				}
-			}
+	return TransitiveViolation(b) == nil
+}
+
+// TransitiveViolation returns a triple (x, y, z) such that xBy
+// and yBz, but not xBz, or nil if b is Transitive.
+func TransitiveViolation(b AbstractInterface) *Violation {
+	return graphOf(b).transitiveViolation()
+}
+
+// transitiveViolation is TransitiveViolation of the snapshot g.
+func (g *graph) transitiveViolation() *Violation {
+	for x := range g.elems {
+		if v := g.intransitive(x); v != nil {
+			return v
 		}
 	}
 
-	return true
+	return nil
+}
+
+// intransitive returns a triple (x, y, z) such that xBy and yBz, but
+// not xBz, or nil if there is none for x.
+func (g *graph) intransitive(x int) *Violation {
+	// for xBy, we need every successor of y to be a successor of x
+	for _, y := range g.succ[x] {
+		if v := g.intransitiveThrough(x, y); v != nil {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// intransitiveThrough returns a triple (x, y, z) such that yBz, but not
+// xBz, or nil if there is none; xBy is assumed. It reads only the rows
+// of x and y.
+func (g *graph) intransitiveThrough(x, y int) *Violation {
+	if z := g.rows[y].firstMissing(g.rows[x]); z >= 0 {
+		ex, ey, ez := g.elems[x], g.elems[y], g.elems[z]
+		return violate("transitive", fmt.Sprintf("(%v, %v) ∈ B and (%v, %v) ∈ B, but (%v, %v) ∉ B", ex, ey, ey, ez, ex, ez), ex, ey, ez)
+	}
+
+	return nil
+}
+
+// intransitivePair returns a triple (x, y, z) or (y, x, z) violating
+// transitivity, or nil if there is none through the pair x, y. It
+// reads only the rows of x and y.
+func (g *graph) intransitivePair(x, y int) *Violation {
+	if g.rows[x].has(y) {
+		if v := g.intransitiveThrough(x, y); v != nil {
+			return v
+		}
+	}
+
+	if g.rows[y].has(x) {
+		return g.intransitiveThrough(y, x)
+	}
+
+	return nil
 }
 
 // Symmetric checks the following condition:
 //	 xBy ⇒  yBx for any x, y ∈ X ≡ Universe
 func Symmetric(b AbstractInterface) bool {
-	elems := b.Universe().Elements()
-
-	for _, x := range elems {
-		for _, y := range elems {
-			if b.ContainsRelation(x, y) {
-				if !b.ContainsRelation(x, y) {
-					return false
-				}
-			}
+	return SymmetricViolation(b) == nil
+}
+
+// SymmetricViolation returns a pair (x, y) such that xBy but not yBx,
+// or nil if b is Symmetric.
+func SymmetricViolation(b AbstractInterface) *Violation {
+	return graphOf(b).symmetricViolation()
+}
+
+// symmetricViolation is SymmetricViolation of the snapshot g.
+func (g *graph) symmetricViolation() *Violation {
+	cols := g.columns()
+
+	// B is symmetric when each row is the corresponding column
+	for x := range g.elems {
+		if y := g.rows[x].firstMissing(cols[x]); y >= 0 {
+			ex, ey := g.elems[x], g.elems[y]
+			return violate("symmetric", fmt.Sprintf("(%v, %v) ∈ B, but (%v, %v) ∉ B", ex, ey, ey, ex), ex, ey)
 		}
 	}
 
-	return true
+	return nil
 }
 
 // AntiSymmetric checks the following condition:
 //	(xBy and yBx) ⇒  (x = y), for any x, y ∈ X
 func AntiSymmetric(b AbstractInterface) bool {
-	elems := b.Universe().Elements()
-	for _, x := range elems {
-		for _, y := range elems {
-			if b.ContainsRelation(x, y) && b.ContainsRelation(y, x) {
-				if x != y {
-					return false
-				}
-			}
+	return AntiSymmetricViolation(b) == nil
+}
+
+// AntiSymmetricViolation returns a pair (x, y) of distinct elements
+// such that xBy and yBx, or nil if b is AntiSymmetric.
+func AntiSymmetricViolation(b AbstractInterface) *Violation {
+	return graphOf(b).antiSymmetricViolation()
+}
+
+// antiSymmetricViolation is AntiSymmetricViolation of the snapshot g.
+func (g *graph) antiSymmetricViolation() *Violation {
+	cols := g.columns()
+
+	for x := range g.elems {
+		if v := g.symmetricPair(x, cols); v != nil {
+			return v
 		}
 	}
 
-	return true
+	return nil
+}
+
+// symmetricPair returns a pair (x, y) such that xBy and yBx, but
+// x ≠ y, or nil if there is none for x; cols are the columns of g.
+func (g *graph) symmetricPair(x int, cols []bitset) *Violation {
+	// the row and column of x may only share x itself
+	both := g.rows[x].clone()
+	both.remove(x)
+
+	if y := both.firstCommon(cols[x]); y >= 0 {
+		return g.mutualPair(x, y)
+	}
+
+	return nil
+}
+
+// mutualPair returns the pair (x, y) if xBy and yBx, but x ≠ y, or nil
+// otherwise. It reads only the rows of x and y.
+func (g *graph) mutualPair(x, y int) *Violation {
+	if x == y || !g.rows[x].has(y) || !g.rows[y].has(x) {
+		return nil
+	}
+
+	ex, ey := g.elems[x], g.elems[y]
+	return violate("antisymmetric", fmt.Sprintf("(%v, %v) ∈ B and (%v, %v) ∈ B, but %v ≠ %v", ex, ey, ey, ex, ex, ey), ex, ey)
 }
 
 // ComposableRelations indicates whether the list of relations can be
@@ -228,22 +468,50 @@ func ComposableRelations(relations []AbstractInterface) bool {
 // WeakOrder checks the B is Complete and Transitive
 // i.e, > and >= defined on the universe of naturals
 func WeakOrder(b AbstractInterface) bool {
-	return Complete(b) && Transitive(b)
+	return WeakOrderViolation(b) == nil
+}
+
+// WeakOrderViolation returns the first counterexample to b being
+// Complete and Transitive, or nil if b is a WeakOrder.
+func WeakOrderViolation(b AbstractInterface) *Violation {
+	return graphOf(b).weakOrderViolation()
+}
+
+// weakOrderViolation is WeakOrderViolation of the snapshot g.
+func (g *graph) weakOrderViolation() *Violation {
+	if v := g.completeViolation(); v != nil {
+		return v
+	}
+
+	return g.transitiveViolation()
 }
 
 // StrictOrder checks that B is a weak order and additionally
-// that B is Symmetric. (This is > verse >=)
+// that B is AntiSymmetric. (This is > verse >=)
 func StrictOrder(b AbstractInterface) bool {
-	return WeakOrder(b) && AntiSymmetric(b)
+	return StrictOrderViolation(b) == nil
+}
+
+// StrictOrderViolation returns the first counterexample to b being
+// a WeakOrder and AntiSymmetric, or nil if b is a StrictOrder.
+func StrictOrderViolation(b AbstractInterface) *Violation {
+	g := graphOf(b)
+
+	if v := g.weakOrderViolation(); v != nil {
+		return v
+	}
+
+	return g.antiSymmetricViolation()
 }
 
 // Reverse constructs the symetric opposite relation.
 // If xBy in the original binary relation, b, then yBx in
-// the reverse binary relation. The reverse of >= is <.
+// the reverse binary relation. The reverse of >= is <=.
+//
+// Reverse is a synonym for Converse. For the relation which
+// holds exactly when b does not, see Complement.
 func Reverse(b AbstractInterface) AbstractInterface {
-	return NewFunctionBinaryRelation(b.Universe(), func(x, y set.Element) bool {
-		return !b.ContainsRelation(x, y)
-	})
+	return Converse(b)
 }
 
 // --- }}}
@@ -261,6 +529,10 @@ type fnBinaryRelation struct {
 
 // NewFunctionBinaryRelation constructs a new BinaryRelation defined
 // by the RelatedPredicate fn, over the universe u.
+//
+// Unlike the physical relations, it does not check that elements are
+// contained in u: ContainsRelation calls fn with whatever it is given.
+// Use TryContainsRelation to check membership first.
 func NewFunctionBinaryRelation(u set.Interface, fn RelatedPredicate) AbstractInterface {
 	return &fnBinaryRelation{
 		universe: u,
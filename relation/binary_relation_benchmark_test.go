@@ -1,6 +1,7 @@
 package relation_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/nlandolfi/set"
@@ -38,3 +39,22 @@ func BenchmarkTransitive(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkTransitiveParallel(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		if v, _ := relation.TransitiveParallel(context.Background(), lessEqual, 0); v != nil {
+			b.Fatalf("The less than or equal to relation should be transitive")
+		}
+	}
+}
+
+func BenchmarkDenseTransitiveClosure(b *testing.B) {
+	d := relation.NewDense(numbers)
+	for i := 0; i < 99; i++ {
+		d.AddRelation(i, i+1)
+	}
+
+	for n := 0; n < b.N; n++ {
+		relation.TransitiveClosure(d)
+	}
+}
@@ -1,6 +1,7 @@
 package relation_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/nlandolfi/set"
@@ -45,3 +46,165 @@ func TestBinaryRelationBasicUsage(t *testing.T) {
 		t.Errorf("Expected binary relation to no longer contain (1, 0), as we removed it")
 	}
 }
+
+func TestBinaryRelationUniverseErrors(t *testing.T) {
+	b := relation.New(set.WithElements(1, 2, 3))
+
+	if err := relation.TryAddRelation(b, 1, 2); err != nil {
+		t.Fatalf("Expected no error adding (1, 2), got %v", err)
+	}
+
+	err := relation.TryAddRelation(b, 1, 4)
+	if !errors.Is(err, relation.ErrNotInUniverse) {
+		t.Fatalf("Expected ErrNotInUniverse adding (1, 4), got %v", err)
+	}
+
+	uerr, ok := err.(*relation.UniverseError)
+	if !ok {
+		t.Fatalf("Expected a *UniverseError, got %T", err)
+	}
+
+	if uerr.Element != 4 || uerr.Position != 2 || uerr.Op != "AddRelation" {
+		t.Errorf("Expected element 4 at position 2 of AddRelation, got %+v", uerr)
+	}
+
+	if _, err := relation.TryContainsRelation(b, 5, 1); err == nil {
+		t.Errorf("Expected an error checking (5, 1)")
+	}
+
+	if err := relation.TryRemoveRelation(b, 0, 1); err == nil {
+		t.Errorf("Expected an error removing (0, 1)")
+	}
+
+	if !b.ContainsRelation(1, 2) {
+		t.Errorf("Expected (1, 2) to remain after failed operations")
+	}
+
+	defer func() {
+		r := recover()
+		if uerr, ok := r.(*relation.UniverseError); !ok || uerr.Op != "RemoveRelation" {
+			t.Errorf("Expected RemoveRelation to panic with a *UniverseError, got %v", r)
+		}
+	}()
+
+	b.RemoveRelation(1, 7)
+}
+
+func TestSymmetric(t *testing.T) {
+	s := set.WithElements(1, 2, 3)
+	b := relation.New(s)
+
+	b.AddRelation(1, 2)
+	b.AddRelation(2, 1)
+
+	if !relation.Symmetric(b) {
+		t.Fatalf("Expected b to be symmetric, got %v", relation.SymmetricViolation(b))
+	}
+
+	b.AddRelation(2, 3)
+
+	if relation.Symmetric(b) {
+		t.Fatal("Expected b to no longer be symmetric, consider (2, 3)")
+	}
+
+	v := relation.SymmetricViolation(b)
+	if v == nil {
+		t.Fatal("Expected a violation of symmetry")
+	}
+
+	if len(v.Elements) != 2 || v.Elements[0] != 2 || v.Elements[1] != 3 {
+		t.Errorf("Expected the witness (2, 3), got %v", v.Elements)
+	}
+}
+
+func TestPropertyViolations(t *testing.T) {
+	s := set.WithElements(1, 2, 3)
+	b := relation.New(s)
+
+	b.AddRelation(1, 2)
+	b.AddRelation(2, 3)
+
+	v := relation.TransitiveViolation(b)
+	if v == nil || v.Property != "transitive" {
+		t.Fatalf("Expected a violation of transitivity, got %v", v)
+	}
+
+	if len(v.Elements) != 3 || v.Elements[0] != 1 || v.Elements[1] != 2 || v.Elements[2] != 3 {
+		t.Errorf("Expected the witness (1, 2, 3), got %v", v.Elements)
+	}
+
+	b.AddRelation(1, 3)
+
+	if v := relation.TransitiveViolation(b); v != nil {
+		t.Errorf("Expected no violation of transitivity, got %v", v)
+	}
+
+	if v := relation.ReflexiveViolation(b); v == nil || len(v.Elements) != 1 {
+		t.Errorf("Expected a single element witnessing irreflexivity, got %v", v)
+	}
+
+	b.AddRelation(2, 1)
+
+	v = relation.AntiSymmetricViolation(b)
+	if v == nil || v.Property != "antisymmetric" {
+		t.Fatalf("Expected a violation of antisymmetry, got %v", v)
+	}
+
+	if v.Error() == "" {
+		t.Errorf("Expected a description of the violation")
+	}
+
+	if relation.AntiSymmetricViolation(equality) != nil {
+		t.Errorf("Expected equality to be antisymmetric")
+	}
+}
+
+func TestBinaryRelationPairs(t *testing.T) {
+	for name, r := range map[string]relation.Interface{
+		"map":   relation.New(set.WithElements(1, 2, 3)),
+		"dense": relation.NewDense(set.WithElements(1, 2, 3)),
+	} {
+		b, ok := r.(relation.Enumerable)
+		if !ok {
+			t.Fatalf("%s: Expected the relation to be Enumerable", name)
+		}
+
+		if b.Size() != 0 || len(b.Pairs()) != 0 {
+			t.Errorf("%s: Expected an empty relation to have no pairs", name)
+		}
+
+		r.AddRelation(1, 2)
+		r.AddRelation(1, 3)
+		r.AddRelation(3, 3)
+		r.AddRelation(1, 2)
+
+		if b.Size() != 3 {
+			t.Errorf("%s: Expected 3 pairs, got %d", name, b.Size())
+		}
+
+		pairs := b.Pairs()
+		if len(pairs) != 3 {
+			t.Fatalf("%s: Expected 3 pairs, got %v", name, pairs)
+		}
+
+		for _, p := range pairs {
+			if !b.ContainsRelation(p.First, p.Second) {
+				t.Errorf("%s: Expected (%v, %v) to be related", name, p.First, p.Second)
+			}
+		}
+
+		if s := b.Successors(1); !set.Equivalent(s, set.WithElements(2, 3)) {
+			t.Errorf("%s: Expected the successors of 1 to be {2, 3}, got %s", name, s)
+		}
+
+		if s := b.Predecessors(3); !set.Equivalent(s, set.WithElements(1, 3)) {
+			t.Errorf("%s: Expected the predecessors of 3 to be {1, 3}, got %s", name, s)
+		}
+
+		r.RemoveRelation(1, 3)
+
+		if b.Size() != 2 || b.Successors(1).Cardinality() != 1 {
+			t.Errorf("%s: Expected (1, 3) to be removed", name)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package choice
+
+import (
+	"fmt"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+// --- Arrow's Properties {{{
+
+// strictly reports whether r strictly prefers x to y.
+func strictly(r relation.AbstractInterface, x, y set.Element) bool {
+	return r.ContainsRelation(x, y) && !r.ContainsRelation(y, x)
+}
+
+// ParetoViolation returns a pair (x, y) such that every voter of p
+// strictly prefers x to y, but rule(p) does not, or nil if rule
+// respects the (weak) Pareto principle on p.
+func ParetoViolation(rule Rule, p *Profile) *relation.Violation {
+	if len(p.voters) == 0 {
+		return nil
+	}
+
+	social := rule(p)
+
+	for i, x := range p.elems {
+		for j, y := range p.elems {
+			if p.support[i][j] == len(p.voters) && !strictly(social, x, y) {
+				return &relation.Violation{
+					Property: "Pareto efficient",
+					Elements: []set.Element{x, y},
+					Reason:   fmt.Sprintf("every voter strictly prefers %v to %v, but the rule does not", x, y),
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// IIAViolation returns a pair (x, y) which every voter ranks alike in
+// p and q, but which rule ranks differently in rule(p) and rule(q), or
+// nil if rule is independent of irrelevant alternatives on p and q.
+//
+// IIAViolation panics with an error wrapping
+// relation.ErrUniverseMismatch unless p and q have the same
+// alternatives and number of voters.
+func IIAViolation(rule Rule, p, q *Profile) *relation.Violation {
+	if len(p.voters) != len(q.voters) || !set.Equivalent(p.alternatives, q.alternatives) {
+		panic(fmt.Errorf("choice: IIAViolation: profiles differ: %w", relation.ErrUniverseMismatch))
+	}
+
+	// alike reports whether r and s rank x and y alike
+	alike := func(r, s relation.AbstractInterface, x, y set.Element) bool {
+		return r.ContainsRelation(x, y) == s.ContainsRelation(x, y) &&
+			r.ContainsRelation(y, x) == s.ContainsRelation(y, x)
+	}
+
+	sp, sq := rule(p), rule(q)
+
+	for i, x := range p.elems {
+	Pairs:
+		for _, y := range p.elems[i+1:] {
+			for v := range p.voters {
+				if !alike(p.voters[v], q.voters[v], x, y) {
+					continue Pairs
+				}
+			}
+
+			if !alike(sp, sq, x, y) {
+				return &relation.Violation{
+					Property: "independent of irrelevant alternatives",
+					Elements: []set.Element{x, y},
+					Reason:   fmt.Sprintf("every voter ranks %v and %v alike in both profiles, but the rule does not", x, y),
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// --- }}}
+
+// --- Exhaustive Checks {{{
+
+// Profiles calls fn with each Profile of voters linear orders over
+// alternatives, of which there are (m!)^voters for m alternatives.
+// Enumeration stops early if fn returns false.
+func Profiles(alternatives set.Interface, voters int, fn func(*Profile) bool) {
+	var rankings []relation.AbstractInterface
+
+	relation.LinearExtensions(relation.New(alternatives), func(order []set.Element) bool {
+		r, _ := Ranking(alternatives, order...)
+		rankings = append(rankings, r)
+		return true
+	})
+
+	// choice[v] is the index into rankings of the preference of voter
+	// v; we count through the choices as a number in base len(rankings)
+	choice := make([]int, voters)
+	preferences := make([]relation.AbstractInterface, voters)
+
+	for {
+		for v, c := range choice {
+			preferences[v] = rankings[c]
+		}
+
+		p, _ := NewProfile(alternatives, preferences...)
+		if !fn(p) {
+			return
+		}
+
+		v := 0
+		for ; v < voters; v++ {
+			if choice[v]++; choice[v] < len(rankings) {
+				break
+			}
+			choice[v] = 0
+		}
+
+		if v == voters {
+			return
+		}
+	}
+}
+
+// Dictator searches for a dictator of rule over the profiles of voters
+// linear orders over alternatives: a voter whose strict preference
+// between any two alternatives is always the rule's, regardless of the
+// preferences of the others. It returns the first such voter, if any.
+//
+// By Arrow's theorem, a Rule which constructs weak orders, is Pareto
+// efficient and independent of irrelevant alternatives has a dictator
+// when there are at least three alternatives. Dictator enumerates all
+// (m!)^voters Profiles, so is practical only for small m and voters.
+func Dictator(rule Rule, alternatives set.Interface, voters int) (int, bool) {
+	candidates := make(map[int]bool, voters)
+	for v := 0; v < voters; v++ {
+		candidates[v] = true
+	}
+
+	Profiles(alternatives, voters, func(p *Profile) bool {
+		social := rule(p)
+
+		for v := range candidates {
+			for _, x := range p.elems {
+				for _, y := range p.elems {
+					if strictly(p.voters[v], x, y) && !strictly(social, x, y) {
+						delete(candidates, v)
+					}
+				}
+			}
+		}
+
+		return len(candidates) > 0
+	})
+
+	for v := 0; v < voters; v++ {
+		if candidates[v] {
+			return v, true
+		}
+	}
+
+	return 0, false
+}
+
+// --- }}}
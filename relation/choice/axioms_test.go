@@ -0,0 +1,73 @@
+package choice_test
+
+import (
+	"testing"
+
+	"github.com/nlandolfi/set/relation"
+	"github.com/nlandolfi/set/relation/choice"
+)
+
+func TestPareto(t *testing.T) {
+	p := profile([]int{2, 1}, "abc", "acb")
+
+	for name, rule := range map[string]choice.Rule{
+		"Borda":   choice.Borda,
+		"Kemeny":  choice.Kemeny,
+		"Schulze": choice.Schulze,
+	} {
+		if v := choice.ParetoViolation(rule, p); v != nil {
+			t.Errorf("%s: Expected the rule to be Pareto efficient, got %v", name, v)
+		}
+	}
+
+	imposed := func(*choice.Profile) relation.AbstractInterface {
+		return rank("cba")
+	}
+
+	if v := choice.ParetoViolation(imposed, p); v == nil || v.Elements[0] != "a" {
+		t.Errorf("Expected an imposed ranking not to be Pareto efficient, got %v", v)
+	}
+}
+
+func TestIIA(t *testing.T) {
+	// every voter ranks a and b alike in p and q, only c moves
+	p := profile([]int{3, 2}, "abc", "bca")
+	q := profile([]int{3, 2}, "abc", "bac")
+
+	v := choice.IIAViolation(choice.Borda, p, q)
+	if v == nil || v.Elements[0] != "a" || v.Elements[1] != "b" {
+		t.Errorf("Expected Borda to depend on c in ranking a and b, got %v", v)
+	}
+
+	dictatorship := func(p *choice.Profile) relation.AbstractInterface {
+		return p.Voter(0)
+	}
+
+	if v := choice.IIAViolation(dictatorship, p, q); v != nil {
+		t.Errorf("Expected a dictatorship to be independent of irrelevant alternatives, got %v", v)
+	}
+}
+
+func TestDictator(t *testing.T) {
+	dictatorship := func(p *choice.Profile) relation.AbstractInterface {
+		return p.Voter(1)
+	}
+
+	if v, ok := choice.Dictator(dictatorship, abc, 2); !ok || v != 1 {
+		t.Errorf("Expected voter 1 to be a dictator, got %d, %v", v, ok)
+	}
+
+	if v, ok := choice.Dictator(choice.Borda, abc, 2); ok {
+		t.Errorf("Expected Borda to have no dictator, got %d", v)
+	}
+
+	count := 0
+	choice.Profiles(abc, 2, func(*choice.Profile) bool {
+		count++
+		return true
+	})
+
+	if count != 36 {
+		t.Errorf("Expected 36 profiles of two voters over three alternatives, got %d", count)
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Package choice aggregates individual preferences into social
+preferences, in the manner of social choice theory.
+
+A voter's preference over a set of alternatives is a weak order R, as
+checked by relation.WeakOrder: xRy means the voter considers x at
+least as good as y. The voter strictly prefers x to y if xRy but not
+yRx, and is indifferent between them if both. A Profile collects the
+preferences of several voters over the same alternatives, and a Rule,
+such as Borda or Schulze, aggregates a Profile into a social
+preference. The properties of Arrow's theorem may then be checked of a
+Rule: see ParetoViolation, IIAViolation and Dictator.
+
+The alternatives are ordered by fmt.Sprint wherever a Rule must break
+a tie, so that results are reproducible.
+*/
+package choice
+
+import (
+	"fmt"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+// --- Profiles {{{
+
+// A Profile is a list of the preferences of voters over a common set
+// of alternatives. A Profile is immutable.
+type Profile struct {
+	alternatives set.Interface
+	voters       []relation.AbstractInterface
+
+	// elems are the alternatives, sorted by fmt.Sprint, and index
+	// their positions
+	elems []set.Element
+	index map[set.Element]int
+
+	// support[i][j] is the number of voters who strictly prefer
+	// elems[i] to elems[j]
+	support [][]int
+}
+
+// NewProfile constructs the Profile of the preferences of voters over
+// alternatives.
+//
+// An error wrapping relation.ErrUniverseMismatch is returned if some
+// preference is not defined over alternatives, and the
+// *relation.Violation if it is not a relation.WeakOrder.
+func NewProfile(alternatives set.Interface, voters ...relation.AbstractInterface) (*Profile, error) {
+	p := &Profile{
+		alternatives: alternatives,
+		voters:       append([]relation.AbstractInterface(nil), voters...),
+		elems:        set.Sorted(alternatives),
+	}
+
+	n := len(p.elems)
+	p.index = make(map[set.Element]int, n)
+	p.support = make([][]int, n)
+
+	for i, e := range p.elems {
+		p.index[e] = i
+		p.support[i] = make([]int, n)
+	}
+
+	for v, r := range voters {
+		if !set.Equivalent(r.Universe(), alternatives) {
+			return nil, fmt.Errorf("choice: voter %d: %w", v, relation.ErrUniverseMismatch)
+		}
+
+		if violation := relation.WeakOrderViolation(r); violation != nil {
+			return nil, violation
+		}
+
+		for i, x := range p.elems {
+			for j, y := range p.elems {
+				if i != j && r.ContainsRelation(x, y) && !r.ContainsRelation(y, x) {
+					p.support[i][j]++
+				}
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// Alternatives returns the set of alternatives over which the voters'
+// preferences are defined.
+func (p *Profile) Alternatives() set.Interface {
+	return p.alternatives
+}
+
+// Voters returns the number of voters.
+func (p *Profile) Voters() int {
+	return len(p.voters)
+}
+
+// Voter returns the preference of the i-th voter.
+func (p *Profile) Voter(i int) relation.AbstractInterface {
+	return p.voters[i]
+}
+
+// Support returns the number of voters who strictly prefer x to y.
+//
+// Support panics with a *relation.UniverseError if x or y is not an
+// alternative.
+func (p *Profile) Support(x, y set.Element) int {
+	i, j := p.position("Support", x, y)
+	return p.support[i][j]
+}
+
+// position finds the positions of x and y, or panics with a
+// *relation.UniverseError.
+func (p *Profile) position(op string, x, y set.Element) (int, int) {
+	i, ok := p.index[x]
+	if !ok {
+		panic(&relation.UniverseError{Op: op, Element: x, Position: 1})
+	}
+
+	j, ok := p.index[y]
+	if !ok {
+		panic(&relation.UniverseError{Op: op, Element: y, Position: 2})
+	}
+
+	return i, j
+}
+
+// --- }}}
+
+// --- Rankings {{{
+
+// Ranking constructs the linear order over alternatives which ranks
+// them in the order given, best first: xRy iff x is listed no later
+// than y. It is a relation.WeakOrder without indifference.
+//
+// An error is returned unless ranking lists every alternative exactly
+// once.
+func Ranking(alternatives set.Interface, ranking ...set.Element) (relation.Interface, error) {
+	if uint(len(ranking)) != alternatives.Cardinality() {
+		return nil, fmt.Errorf("choice: ranking lists %d alternatives, not %d", len(ranking), alternatives.Cardinality())
+	}
+
+	listed := make(map[set.Element]bool, len(ranking))
+
+	for _, x := range ranking {
+		if !alternatives.Contains(x) {
+			return nil, &relation.UniverseError{Op: "Ranking", Element: x, Position: 1}
+		}
+
+		if listed[x] {
+			return nil, fmt.Errorf("choice: ranking lists %v twice", x)
+		}
+
+		listed[x] = true
+	}
+
+	r := relation.New(alternatives)
+
+	for i, x := range ranking {
+		for _, y := range ranking[i:] {
+			r.AddRelation(x, y)
+		}
+	}
+
+	return r, nil
+}
+
+// order constructs the social preference which ranks elems[i] at
+// least as high as elems[j] iff score[i] >= score[j].
+func (p *Profile) order(score []float64) relation.AbstractInterface {
+	r := relation.New(p.alternatives)
+
+	for i, x := range p.elems {
+		for j, y := range p.elems {
+			if score[i] >= score[j] {
+				r.AddRelation(x, y)
+			}
+		}
+	}
+
+	return r
+}
+
+// --- }}}
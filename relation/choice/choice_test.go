@@ -0,0 +1,82 @@
+package choice_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+	"github.com/nlandolfi/set/relation/choice"
+)
+
+var abc = set.WithElements("a", "b", "c")
+
+// rank constructs the ranking of abc given by a string, e.g., "bca"
+func rank(order string) relation.AbstractInterface {
+	ranking := make([]set.Element, len(order))
+	for i, r := range order {
+		ranking[i] = string(r)
+	}
+
+	r, err := choice.Ranking(abc, ranking...)
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// profile constructs the profile of counts[i] voters ranking orders[i]
+func profile(counts []int, orders ...string) *choice.Profile {
+	var voters []relation.AbstractInterface
+
+	for i, order := range orders {
+		for n := 0; n < counts[i]; n++ {
+			voters = append(voters, rank(order))
+		}
+	}
+
+	p, err := choice.NewProfile(abc, voters...)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+func TestRanking(t *testing.T) {
+	r := rank("bca")
+
+	if !relation.WeakOrder(r) || !r.ContainsRelation("b", "a") || r.ContainsRelation("a", "c") {
+		t.Errorf("Expected b > c > a, got %v", r)
+	}
+
+	if _, err := choice.Ranking(abc, "a", "b"); err == nil {
+		t.Errorf("Expected a ranking missing c to be refused")
+	}
+
+	if _, err := choice.Ranking(abc, "a", "b", "b"); err == nil {
+		t.Errorf("Expected a ranking listing b twice to be refused")
+	}
+
+	if _, err := choice.Ranking(abc, "a", "b", "d"); !errors.Is(err, relation.ErrNotInUniverse) {
+		t.Errorf("Expected d to be reported as not an alternative, got %v", err)
+	}
+}
+
+func TestNewProfile(t *testing.T) {
+	p := profile([]int{3, 2}, "abc", "bca")
+
+	if p.Voters() != 5 || p.Support("a", "b") != 3 || p.Support("b", "a") != 2 || p.Support("c", "a") != 2 {
+		t.Errorf("Expected a to be preferred to b by 3 and b to a by 2")
+	}
+
+	if _, err := choice.NewProfile(abc, relation.New(abc)); err == nil {
+		t.Errorf("Expected an empty relation not to be a preference")
+	}
+
+	other := relation.New(set.WithElements("a", "b"))
+	if _, err := choice.NewProfile(abc, other); !errors.Is(err, relation.ErrUniverseMismatch) {
+		t.Errorf("Expected a preference over other alternatives to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,298 @@
+package choice
+
+import (
+	"fmt"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+// --- Rules {{{
+
+// A Rule aggregates the preferences of a Profile into a social
+// preference over its alternatives.
+//
+// Every Rule of this package but Majority constructs a
+// relation.WeakOrder.
+type Rule func(p *Profile) relation.AbstractInterface
+
+// --- }}}
+
+// --- Pairwise Majority {{{
+
+// Majority constructs the pairwise majority relation of p: x is at
+// least as good as y if at least as many voters strictly prefer x to
+// y as prefer y to x.
+//
+// Majority is Complete, but need not be Transitive: with voters
+// ranking a > b > c, b > c > a and c > a > b, a majority prefers a to
+// b, b to c, and c to a. This is Condorcet's paradox.
+func Majority(p *Profile) relation.AbstractInterface {
+	r := relation.New(p.alternatives)
+
+	for i, x := range p.elems {
+		for j, y := range p.elems {
+			if p.support[i][j] >= p.support[j][i] {
+				r.AddRelation(x, y)
+			}
+		}
+	}
+
+	return r
+}
+
+// CondorcetWinner returns the alternative which a majority strictly
+// prefers to each other alternative, if there is one.
+func CondorcetWinner(p *Profile) (set.Element, bool) {
+Candidates:
+	for i, x := range p.elems {
+		for j := range p.elems {
+			if i != j && p.support[i][j] <= p.support[j][i] {
+				continue Candidates
+			}
+		}
+
+		return x, true
+	}
+
+	return nil, false
+}
+
+// --- }}}
+
+// --- Scoring Rules {{{
+
+// BordaScores computes the Borda count of each alternative: the number
+// of (voter, alternative) pairs such that the voter strictly prefers
+// it to the other alternative, plus half the number of those such that
+// the voter is indifferent between them.
+func BordaScores(p *Profile) map[set.Element]float64 {
+	scores := make(map[set.Element]float64, len(p.elems))
+	for i, x := range p.elems {
+		scores[x] = p.borda(i)
+	}
+	return scores
+}
+
+// borda computes the Borda count of elems[i].
+func (p *Profile) borda(i int) float64 {
+	score := 0.0
+
+	for j := range p.elems {
+		if i == j {
+			continue
+		}
+
+		indifferent := len(p.voters) - p.support[i][j] - p.support[j][i]
+		score += float64(p.support[i][j]) + float64(indifferent)/2
+	}
+
+	return score
+}
+
+// Borda ranks the alternatives of p by their BordaScores.
+func Borda(p *Profile) relation.AbstractInterface {
+	score := make([]float64, len(p.elems))
+	for i := range p.elems {
+		score[i] = p.borda(i)
+	}
+
+	return p.order(score)
+}
+
+// CopelandScores computes the Copeland score of each alternative: the
+// number of alternatives it beats by pairwise majority, less the
+// number which beat it.
+func CopelandScores(p *Profile) map[set.Element]float64 {
+	scores := make(map[set.Element]float64, len(p.elems))
+	for i, x := range p.elems {
+		scores[x] = p.copeland(i)
+	}
+	return scores
+}
+
+// copeland computes the Copeland score of elems[i].
+func (p *Profile) copeland(i int) float64 {
+	score := 0.0
+
+	for j := range p.elems {
+		switch {
+		case p.support[i][j] > p.support[j][i]:
+			score++
+		case p.support[i][j] < p.support[j][i]:
+			score--
+		}
+	}
+
+	return score
+}
+
+// Copeland ranks the alternatives of p by their CopelandScores.
+func Copeland(p *Profile) relation.AbstractInterface {
+	score := make([]float64, len(p.elems))
+	for i := range p.elems {
+		score[i] = p.copeland(i)
+	}
+
+	return p.order(score)
+}
+
+// --- }}}
+
+// --- Kemeny {{{
+
+// KemenyLimit is the most alternatives Kemeny will rank: its tables
+// hold 2 × 2^20 ints, some 16MB, and take about a second to fill.
+const KemenyLimit = 20
+
+// Kemeny ranks the alternatives of p by a Kemeny-Young ranking: a
+// linear order which maximises the number of (voter, pair) agreements,
+// the pairs x, y ranked x above y such that the voter strictly prefers
+// x to y. Equivalently, it minimises the total Kendall tau distance to
+// the voters' preferences. Of several such rankings, one is chosen
+// which favours the alternatives first in the order of fmt.Sprint.
+//
+// Finding a Kemeny ranking is NP-hard. Kemeny uses dynamic programming
+// over the subsets of alternatives, which costs O(2^m m^2) time and
+// O(2^m) space for m alternatives.
+//
+// Kemeny panics if p has more than KemenyLimit alternatives.
+func Kemeny(p *Profile) relation.AbstractInterface {
+	m := len(p.elems)
+	if m > KemenyLimit {
+		panic(fmt.Sprintf("choice: Kemeny: %d alternatives exceed KemenyLimit (%d)", m, KemenyLimit))
+	}
+
+	full := 1<<uint(m) - 1
+
+	// best[s] is the most agreements achieved by ranking the subset s
+	// of alternatives above the rest, and last[s] the alternative
+	// ranked lowest of s to achieve it
+	best := make([]int, full+1)
+	last := make([]int, full+1)
+
+	for s := 1; s <= full; s++ {
+		best[s] = -1
+
+		for x := 0; x < m; x++ {
+			if s&(1<<uint(x)) == 0 {
+				continue
+			}
+
+			// rank x below every other alternative of s
+			rest := s &^ (1 << uint(x))
+			agreements := best[rest]
+			for y := 0; y < m; y++ {
+				if rest&(1<<uint(y)) != 0 {
+					agreements += p.support[y][x]
+				}
+			}
+
+			// prefer the later x, as it is ranked lowest, so that
+			// earlier alternatives are ranked higher
+			if agreements >= best[s] {
+				best[s], last[s] = agreements, x
+			}
+		}
+	}
+
+	score := make([]float64, m)
+	for s, position := full, 0; s != 0; position++ {
+		x := last[s]
+		score[x] = float64(position)
+		s &^= 1 << uint(x)
+	}
+
+	return p.order(score)
+}
+
+// --- }}}
+
+// --- Schulze {{{
+
+// Schulze ranks the alternatives of p by the Schulze method. The
+// strength of a path x = a0, a1, ..., ak = y is that of its weakest
+// link, where the link (a, b) has strength Support(a, b) if a majority
+// prefers a to b, and no link otherwise. Then x beats y if the
+// strongest path from x to y is stronger than the strongest path from
+// y to x.
+//
+// Beating is transitive, but the ties between alternatives neither of
+// which beats the other need not be, so the alternatives are ranked in
+// levels: first the Schulze winners, which no alternative beats, then
+// the winners among the rest, and so on. The result is a weak order in
+// which x is strictly above y whenever x beats y, and ranks a
+// CondorcetWinner first.
+func Schulze(p *Profile) relation.AbstractInterface {
+	m := len(p.elems)
+
+	// strength[i][j] is the strength of the strongest path from i to j
+	strength := make([][]int, m)
+	for i := range strength {
+		strength[i] = make([]int, m)
+		for j := range strength[i] {
+			if p.support[i][j] > p.support[j][i] {
+				strength[i][j] = p.support[i][j]
+			}
+		}
+	}
+
+	// widest paths, by Floyd-Warshall
+	for k := 0; k < m; k++ {
+		for i := 0; i < m; i++ {
+			if i == k {
+				continue
+			}
+
+			for j := 0; j < m; j++ {
+				if j == i || j == k {
+					continue
+				}
+
+				through := strength[i][k]
+				if strength[k][j] < through {
+					through = strength[k][j]
+				}
+
+				if through > strength[i][j] {
+					strength[i][j] = through
+				}
+			}
+		}
+	}
+
+	// score[i] is minus the level of elems[i]; each level is the
+	// alternatives which no remaining alternative beats
+	score := make([]float64, m)
+	ranked := make([]bool, m)
+
+	for level, remaining := 0, m; remaining > 0; level++ {
+		var winners []int
+
+	Candidates:
+		for j := 0; j < m; j++ {
+			if ranked[j] {
+				continue
+			}
+
+			for i := 0; i < m; i++ {
+				if !ranked[i] && strength[i][j] > strength[j][i] {
+					continue Candidates
+				}
+			}
+
+			winners = append(winners, j)
+		}
+
+		for _, j := range winners {
+			score[j] = float64(-level)
+			ranked[j] = true
+		}
+
+		remaining -= len(winners)
+	}
+
+	return p.order(score)
+}
+
+// --- }}}
@@ -0,0 +1,132 @@
+package choice_test
+
+import (
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+	"github.com/nlandolfi/set/relation/choice"
+)
+
+// strictly reports whether r ranks x above y
+func strictly(r relation.AbstractInterface, x, y string) bool {
+	return r.ContainsRelation(x, y) && !r.ContainsRelation(y, x)
+}
+
+func TestMajority(t *testing.T) {
+	paradox := profile([]int{1, 1, 1}, "abc", "bca", "cab")
+	m := choice.Majority(paradox)
+
+	if !relation.Complete(m) || relation.Transitive(m) {
+		t.Errorf("Expected the majority relation to be complete, but not transitive")
+	}
+
+	if w, ok := choice.CondorcetWinner(paradox); ok {
+		t.Errorf("Expected no Condorcet winner, got %v", w)
+	}
+
+	p := profile([]int{3, 2}, "abc", "bca")
+	if w, ok := choice.CondorcetWinner(p); !ok || w != "a" {
+		t.Errorf("Expected a to be the Condorcet winner, got %v", w)
+	}
+}
+
+func TestScoringRules(t *testing.T) {
+	p := profile([]int{3, 2}, "abc", "bca")
+
+	scores := choice.BordaScores(p)
+	if scores["a"] != 6 || scores["b"] != 7 || scores["c"] != 2 {
+		t.Errorf("Expected Borda scores a: 6, b: 7, c: 2, got %v", scores)
+	}
+
+	// though a is the Condorcet winner
+	if b := choice.Borda(p); !relation.WeakOrder(b) || !strictly(b, "b", "a") || !strictly(b, "a", "c") {
+		t.Errorf("Expected Borda to rank b > a > c")
+	}
+
+	paradox := profile([]int{1, 1, 1}, "abc", "bca", "cab")
+	c := choice.Copeland(paradox)
+
+	if !relation.WeakOrder(c) || !c.ContainsRelation("a", "b") || !c.ContainsRelation("b", "a") {
+		t.Errorf("Expected Copeland to tie the alternatives of Condorcet's paradox")
+	}
+
+	if s := choice.CopelandScores(p); s["a"] != 2 || s["b"] != 0 || s["c"] != -2 {
+		t.Errorf("Expected Copeland scores a: 2, b: 0, c: -2, got %v", s)
+	}
+}
+
+func TestKemenySchulze(t *testing.T) {
+	// a beats b and b beats c by 5 to 2, but c beats a by 4 to 3
+	p := profile([]int{3, 2, 2}, "abc", "bca", "cab")
+
+	for name, rule := range map[string]choice.Rule{
+		"Kemeny":  choice.Kemeny,
+		"Schulze": choice.Schulze,
+	} {
+		r := rule(p)
+
+		if !relation.WeakOrder(r) {
+			t.Errorf("%s: Expected a weak order, got %v", name, relation.WeakOrderViolation(r))
+		}
+
+		if !strictly(r, "a", "b") || !strictly(r, "b", "c") {
+			t.Errorf("%s: Expected the ranking a > b > c", name)
+		}
+	}
+
+	// two voters, who agree only that a > c and b > c, d, e; no two
+	// of a, b beat one another, nor do c, d, e, though a beats c
+	five := set.WithElements("a", "b", "c", "d", "e")
+	first, _ := choice.Ranking(five, "a", "b", "c", "d", "e")
+	second, _ := choice.Ranking(five, "b", "e", "d", "a", "c")
+
+	tied, err := choice.NewProfile(five, first, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := choice.Schulze(tied)
+	if !relation.WeakOrder(s) {
+		t.Errorf("Schulze: Expected a weak order, got %v", relation.WeakOrderViolation(s))
+	}
+
+	if !s.ContainsRelation("a", "b") || !s.ContainsRelation("b", "a") || !strictly(s, "a", "d") || !strictly(s, "b", "c") {
+		t.Errorf("Schulze: Expected the ranking a ~ b > c ~ d ~ e")
+	}
+
+	// too many alternatives to rank
+	for _, m := range []int{choice.KemenyLimit + 1, 64} {
+		func() {
+			many := set.New()
+			for i := 0; i < m; i++ {
+				many.Add(i)
+			}
+
+			p, err := choice.NewProfile(many, relation.NewFunctionBinaryRelation(many, func(x, y set.Element) bool {
+				return x.(int) <= y.(int)
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected Kemeny to refuse %d alternatives", m)
+				}
+			}()
+
+			choice.Kemeny(p)
+		}()
+	}
+
+	// a Condorcet winner is ranked first
+	q := profile([]int{3, 2}, "abc", "bca")
+
+	for _, rule := range []choice.Rule{choice.Kemeny, choice.Schulze} {
+		r := rule(q)
+		if !strictly(r, "a", "b") || !strictly(r, "a", "c") {
+			t.Errorf("Expected the Condorcet winner a to be ranked first")
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package relation
+
+// --- Closures {{{
+
+// The closures in this file are physical relations over the universe
+// of their argument, computed from a snapshot of it. They do not
+// reflect later changes to the argument. A closure is dense if its
+// argument is, see NewDense.
+
+// ReflexiveClosure constructs the smallest Reflexive relation
+// containing b: B ∪ {(x, x) | x ∈ X}.
+func ReflexiveClosure(b AbstractInterface) Interface {
+	g := graphOf(b)
+
+	for i := range g.rows {
+		g.rows[i].add(i)
+	}
+
+	g.reindex()
+	return g.physical(b)
+}
+
+// SymmetricClosure constructs the smallest Symmetric relation
+// containing b: B ∪ B⁻¹.
+func SymmetricClosure(b AbstractInterface) Interface {
+	g := graphOf(b)
+	cols := g.columns()
+
+	for i := range g.rows {
+		g.rows[i].union(cols[i])
+	}
+
+	g.reindex()
+	return g.physical(b)
+}
+
+// TransitiveClosure constructs the smallest Transitive relation
+// containing b, B⁺: x B⁺ y iff there exist x = x0, x1, ..., xk = y
+// with k ≥ 1 such that x0 B x1, ..., x(k-1) B xk.
+//
+// The closure is computed over the strongly connected components of b,
+// which is fast for sparse relations.
+func TransitiveClosure(b AbstractInterface) Interface {
+	g := graphOf(b)
+	g.closeTransitive()
+	return g.physical(b)
+}
+
+// ReflexiveTransitiveClosure constructs the smallest preorder
+// (Reflexive and Transitive relation) containing b, B*.
+func ReflexiveTransitiveClosure(b AbstractInterface) Interface {
+	g := graphOf(b)
+
+	for i := range g.rows {
+		g.rows[i].add(i)
+	}
+
+	g.reindex()
+	g.closeTransitive()
+	return g.physical(b)
+}
+
+// EquivalenceClosure constructs the smallest equivalence relation
+// (Reflexive, Symmetric and Transitive relation) containing b.
+func EquivalenceClosure(b AbstractInterface) Interface {
+	g := graphOf(b)
+	n := len(g.elems)
+
+	// union-find over the undirected edges of b
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for x, succ := range g.succ {
+		for _, y := range succ {
+			parent[find(x)] = find(y)
+		}
+	}
+
+	classes := make(map[int]bitset)
+	for i := 0; i < n; i++ {
+		r := find(i)
+		if classes[r] == nil {
+			classes[r] = newBitset(n)
+		}
+		classes[r].add(i)
+	}
+
+	for i := range g.rows {
+		g.rows[i] = classes[find(i)].clone()
+	}
+
+	g.reindex()
+	return g.physical(b)
+}
+
+// TransitiveReduction constructs a smallest relation with the same
+// TransitiveClosure as b.
+//
+// If b is Acyclic, its reduction is unique and contained in b: it is
+// the relation drawn by a Hasse diagram. Otherwise each strongly
+// connected component of b is reduced to a single cycle through its
+// elements, and the components are joined by a single pair each, in
+// which case the reduction need not be contained in b. An element
+// related to itself, but to no other element of its component, keeps
+// its pair (x, x).
+func TransitiveReduction(b AbstractInterface) Interface {
+	return graphOf(b).reduceTransitive().physical(b)
+}
+
+// closeTransitive replaces g with its transitive closure.
+func (g *graph) closeTransitive() {
+	n := len(g.elems)
+	comps, comp := g.components()
+
+	// reach[c] is the set of vertices reachable from component c; as the
+	// components are in reverse topological order, those of successors
+	// are complete before they are needed
+	reach := make([]bitset, len(comps))
+
+	for c, members := range comps {
+		reach[c] = newBitset(n)
+
+		cyclic := len(members) > 1
+		for _, v := range members {
+			for _, w := range g.succ[v] {
+				if d := comp[w]; d != c {
+					reach[c].union(reach[d])
+					reach[c].add(w)
+				} else {
+					cyclic = true
+				}
+			}
+		}
+
+		if cyclic {
+			for _, v := range members {
+				reach[c].add(v)
+			}
+		}
+
+		for _, v := range members {
+			g.rows[v] = reach[c].clone()
+		}
+	}
+
+	g.reindex()
+}
+
+// reduceTransitive constructs a transitive reduction of g, as
+// documented by TransitiveReduction.
+func (g *graph) reduceTransitive() *graph {
+	comps, comp := g.components()
+	k := len(comps)
+
+	// successor components, and those reachable in one or more steps
+	next := make([]bitset, k)
+	reach := make([]bitset, k)
+
+	for c, members := range comps {
+		next[c] = newBitset(k)
+		reach[c] = newBitset(k)
+
+		for _, v := range members {
+			for _, w := range g.succ[v] {
+				if d := comp[w]; d != c {
+					next[c].add(d)
+				}
+			}
+		}
+
+		for _, d := range next[c].members() {
+			reach[c].union(reach[d])
+			reach[c].add(d)
+		}
+	}
+
+	r := newGraph(g.elems)
+
+	for c, members := range comps {
+		// d is redundant if it may be reached through another successor
+		redundant := newBitset(k)
+		for _, d := range next[c].members() {
+			redundant.union(reach[d])
+		}
+
+		for _, d := range next[c].members() {
+			if redundant.has(d) {
+				continue
+			}
+
+			// join the components by a pair of b
+			target := componentSet(comps[d], len(g.elems))
+			x, y := members[0], comps[d][0]
+			for _, v := range members {
+				if w := g.rows[v].firstCommon(target); w >= 0 {
+					x, y = v, w
+					break
+				}
+			}
+
+			r.rows[x].add(y)
+		}
+
+		switch {
+		case len(members) > 1:
+			for i, v := range members {
+				r.rows[v].add(members[(i+1)%len(members)])
+			}
+		case g.rows[members[0]].has(members[0]):
+			r.rows[members[0]].add(members[0])
+		}
+	}
+
+	r.reindex()
+	return r
+}
+
+// componentSet constructs the bitset of the vertices in members.
+func componentSet(members []int, n int) bitset {
+	s := newBitset(n)
+	for _, v := range members {
+		s.add(v)
+	}
+	return s
+}
+
+// --- }}}
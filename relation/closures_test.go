@@ -0,0 +1,136 @@
+package relation_test
+
+import (
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+// chain constructs the relation 0 → 1 → ... → n-1 over {0, ..., n-1}.
+func chain(n int) relation.Interface {
+	s := set.New()
+	for i := 0; i < n; i++ {
+		s.Add(i)
+	}
+
+	b := relation.New(s)
+	for i := 0; i+1 < n; i++ {
+		b.AddRelation(i, i+1)
+	}
+
+	return b
+}
+
+// equal reports whether r and s contain the same pairs over the universe of r.
+func equal(r, s relation.AbstractInterface) bool {
+	for _, x := range r.Universe().Elements() {
+		for _, y := range r.Universe().Elements() {
+			if r.ContainsRelation(x, y) != s.ContainsRelation(x, y) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func TestTransitiveClosure(t *testing.T) {
+	b := chain(10)
+	c := relation.TransitiveClosure(b)
+
+	if !relation.Transitive(c) {
+		t.Fatalf("Expected the closure to be transitive, got %v", relation.TransitiveViolation(c))
+	}
+
+	if c.Universe() != b.Universe() {
+		t.Errorf("Expected the closure to be defined over the same universe")
+	}
+
+	if !equal(c, relation.NewFunctionBinaryRelation(b.Universe(), func(x, y set.Element) bool {
+		return x.(int) < y.(int)
+	})) {
+		t.Errorf("Expected the transitive closure of a chain to be <")
+	}
+
+	b.AddRelation(9, 0)
+	c = relation.TransitiveClosure(b)
+
+	for _, x := range b.Universe().Elements() {
+		for _, y := range b.Universe().Elements() {
+			if !c.ContainsRelation(x, y) {
+				t.Fatalf("Expected every pair to be in the closure of a cycle, missing (%v, %v)", x, y)
+			}
+		}
+	}
+}
+
+func TestEquivalenceClosure(t *testing.T) {
+	b := relation.New(set.WithElements(1, 2, 3, 4, 5))
+
+	b.AddRelation(1, 2)
+	b.AddRelation(3, 2)
+	b.AddRelation(4, 5)
+
+	e := relation.EquivalenceClosure(b)
+
+	if !relation.Reflexive(e) || !relation.Symmetric(e) || !relation.Transitive(e) {
+		t.Fatal("Expected the equivalence closure to be an equivalence relation")
+	}
+
+	if !e.ContainsRelation(1, 3) || e.ContainsRelation(1, 4) || !e.ContainsRelation(5, 4) {
+		t.Errorf("Expected classes {1, 2, 3} and {4, 5}")
+	}
+
+	r := relation.ReflexiveClosure(b)
+	if !relation.Reflexive(r) || !r.ContainsRelation(1, 2) || r.ContainsRelation(2, 1) {
+		t.Errorf("Expected the reflexive closure to add only (x, x)")
+	}
+
+	s := relation.SymmetricClosure(b)
+	if !relation.Symmetric(s) || !s.ContainsRelation(2, 1) || s.ContainsRelation(1, 1) {
+		t.Errorf("Expected the symmetric closure to add only converse pairs")
+	}
+
+	p := relation.ReflexiveTransitiveClosure(b)
+	if !relation.Reflexive(p) || !relation.Transitive(p) || p.ContainsRelation(1, 3) {
+		t.Errorf("Expected the reflexive transitive closure to be the smallest preorder")
+	}
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	r := relation.TransitiveReduction(lessEqual)
+
+	if !equal(r, relation.NewFunctionBinaryRelation(numbers, func(x, y set.Element) bool {
+		return x == y || y.(int) == x.(int)+1
+	})) {
+		t.Errorf("Expected the reduction of ≤ to be the successor relation with loops")
+	}
+
+	r = relation.TransitiveReduction(lessThan)
+	if !equal(relation.TransitiveClosure(r), lessThan) {
+		t.Errorf("Expected the closure of the reduction of < to be <")
+	}
+
+	b := chain(5)
+	b.AddRelation(4, 0)
+	b.AddRelation(0, 2)
+
+	r = relation.TransitiveReduction(b)
+	if !equal(relation.TransitiveClosure(r), relation.TransitiveClosure(b)) {
+		t.Errorf("Expected the reduction of a cyclic relation to have the same closure")
+	}
+
+	count := 0
+	for _, x := range b.Universe().Elements() {
+		for _, y := range b.Universe().Elements() {
+			if r.ContainsRelation(x, y) {
+				count++
+			}
+		}
+	}
+
+	if count != 5 {
+		t.Errorf("Expected the reduction of a cycle of 5 elements to have 5 pairs, got %d", count)
+	}
+}
@@ -0,0 +1,163 @@
+package relation
+
+import "github.com/nlandolfi/set"
+
+// --- Dense Binary Relation {{{
+
+// NewDense constructs a new, empty BinaryRelation over universe
+// represented as a bit matrix: the elements of the universe are
+// numbered by their position, and xBy is a single bit.
+//
+// A dense relation costs n^2 bits, where n = |universe|, regardless of
+// how many pairs it contains, but membership is a single lookup rather
+// than the deep check of set.Interface.Contains, and the closures,
+// compositions and property checks of this package operate on it a
+// machine word at a time. It suits universes of up to a few thousand
+// elements.
+//
+// The universe is indexed when the relation is constructed, and must
+// not be modified afterwards. Elements are found in the index by ==,
+// so an element which is itself a set must be the same set.Interface
+// value as was added to the universe.
+func NewDense(universe set.Interface) Interface {
+	return newDense(universe, newGraph(universe.Elements()))
+}
+
+// newDense constructs a dense relation over universe, whose
+// elements are those of g, sharing the rows of g.
+func newDense(universe set.Interface, g *graph) *denseRelation {
+	return &denseRelation{
+		universe: universe,
+		elems:    g.elems,
+		index:    g.index,
+		rows:     g.rows,
+	}
+}
+
+// denseRelation is a bit matrix representation of a binary relation
+type denseRelation struct {
+	universe set.Interface
+	elems    []set.Element
+	index    map[set.Element]int
+	rows     []bitset
+}
+
+// Universe returns the set over which the binary relation is defined.
+func (d *denseRelation) Universe() set.Interface {
+	return d.universe
+}
+
+// position finds the positions of e1 and e2, or panics with a
+// *UniverseError.
+func (d *denseRelation) position(op string, e1, e2 set.Element) (int, int) {
+	i, ok := d.index[e1]
+	if !ok {
+		panic(&UniverseError{Op: op, Element: e1, Position: 1})
+	}
+
+	j, ok := d.index[e2]
+	if !ok {
+		panic(&UniverseError{Op: op, Element: e2, Position: 2})
+	}
+
+	return i, j
+}
+
+// AddRelation notes that e1 is related to e2.
+//
+// AddRelation panics with a *UniverseError if either element is not
+// contained in the universe; see TryAddRelation.
+func (d *denseRelation) AddRelation(e1, e2 set.Element) {
+	i, j := d.position("AddRelation", e1, e2)
+	d.rows[i].add(j)
+}
+
+// RemoveRelation is the inverse operation of AddRelation.
+//
+// RemoveRelation panics with a *UniverseError if either element is not
+// contained in the universe; see TryRemoveRelation.
+func (d *denseRelation) RemoveRelation(e1, e2 set.Element) {
+	i, j := d.position("RemoveRelation", e1, e2)
+	d.rows[i].remove(j)
+}
+
+// ContainsRelation determines whether e1 is related to e2.
+//
+// ContainsRelation panics with a *UniverseError if either element is
+// not contained in the universe; see TryContainsRelation.
+func (d *denseRelation) ContainsRelation(e1, e2 set.Element) bool {
+	i, j := d.position("ContainsRelation", e1, e2)
+	return d.rows[i].has(j)
+}
+
+// Pairs retrieves a slice of all related pairs, ordered by the
+// position of their elements in the universe when d was constructed.
+func (d *denseRelation) Pairs() []set.Tuple {
+	pairs := make([]set.Tuple, 0, d.Size())
+
+	for i, row := range d.rows {
+		for _, j := range row.members() {
+			pairs = append(pairs, set.Tuple{First: d.elems[i], Second: d.elems[j]})
+		}
+	}
+
+	return pairs
+}
+
+// Size retrieves the number of related pairs.
+func (d *denseRelation) Size() uint {
+	size := 0
+	for _, row := range d.rows {
+		size += row.count()
+	}
+	return uint(size)
+}
+
+// Successors constructs the set of elements to which e is related.
+//
+// Successors panics with a *UniverseError if e is not contained in the
+// universe.
+func (d *denseRelation) Successors(e set.Element) set.Interface {
+	i, _ := d.position("Successors", e, e)
+
+	s := set.New()
+	for _, j := range d.rows[i].members() {
+		s.Add(d.elems[j])
+	}
+	return s
+}
+
+// Predecessors constructs the set of elements which are related to e.
+//
+// Predecessors panics with a *UniverseError if e is not contained in
+// the universe.
+func (d *denseRelation) Predecessors(e set.Element) set.Interface {
+	_, j := d.position("Predecessors", e, e)
+
+	s := set.New()
+	for i, row := range d.rows {
+		if row.has(j) {
+			s.Add(d.elems[i])
+		}
+	}
+	return s
+}
+
+// graph constructs a snapshot of d, copying its rows.
+func (d *denseRelation) graph() *graph {
+	g := &graph{
+		elems: d.elems,
+		index: d.index,
+		rows:  make([]bitset, len(d.rows)),
+		succ:  make([][]int, len(d.rows)),
+	}
+
+	for i, row := range d.rows {
+		g.rows[i] = row.clone()
+	}
+
+	g.reindex()
+	return g
+}
+
+// --- }}}
@@ -0,0 +1,72 @@
+package relation_test
+
+import (
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+func TestDenseBasicUsage(t *testing.T) {
+	s := set.WithElements(0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	d := relation.NewDense(s)
+
+	if d.Universe() != s {
+		t.Errorf("Expected d's universe to be the set given in construction")
+	}
+
+	for i := 0; i < 9; i++ {
+		d.AddRelation(i+1, i)
+	}
+
+	if !d.ContainsRelation(3, 2) || d.ContainsRelation(2, 3) {
+		t.Errorf("Expected the dense relation to contain (3, 2), but not (2, 3)")
+	}
+
+	d.RemoveRelation(3, 2)
+
+	if d.ContainsRelation(3, 2) {
+		t.Errorf("Expected the dense relation to no longer contain (3, 2)")
+	}
+
+	if _, err := relation.TryContainsRelation(d, 10, 1); err == nil {
+		t.Errorf("Expected an error checking (10, 1)")
+	}
+
+	defer func() {
+		if _, ok := recover().(*relation.UniverseError); !ok {
+			t.Errorf("Expected AddRelation to panic with a *UniverseError")
+		}
+	}()
+
+	d.AddRelation(1, 10)
+}
+
+func TestDenseClosures(t *testing.T) {
+	d := relation.NewDense(numbers)
+	for i := 0; i < 99; i++ {
+		d.AddRelation(i, i+1)
+	}
+
+	c := relation.TransitiveClosure(d)
+	if !equal(c, lessThan) {
+		t.Fatal("Expected the transitive closure of the successor relation to be <")
+	}
+
+	if !relation.Transitive(c) || relation.Transitive(d) {
+		t.Errorf("Expected the closure, but not the successor relation, to be transitive")
+	}
+
+	plusTwo := relation.Materialize(relation.Compose(d, d))
+	if !plusTwo.ContainsRelation(3, 5) || plusTwo.ContainsRelation(3, 4) {
+		t.Errorf("Expected the composition of successor with itself to be +2")
+	}
+
+	if !equal(relation.Materialize(relation.Compose(lessEqual, d)), relation.Compose(lessEqual, d)) {
+		t.Errorf("Expected composition of mixed representations to match its lazy form")
+	}
+
+	if !relation.Symmetric(relation.SymmetricClosure(d)) || !relation.Complete(relation.ReflexiveClosure(c)) {
+		t.Errorf("Expected the closures of a dense relation to have their properties")
+	}
+}
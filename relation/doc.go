@@ -0,0 +1,33 @@
+/*
+Package relation defines the interfaces for working with binary
+relations over a set, and provides implementations of a map backed
+binary relation and a predicate backed binary relation.
+
+Errors:
+
+A relation is defined over a universe, and every element given to
+it must be a member of that universe. Passing an element which is
+not is a programming error: the methods of the relations constructed
+by this package (AddRelation, RemoveRelation and ContainsRelation)
+panic with a *UniverseError. Likewise, the operations which combine
+relations, such as Compose, panic with an error wrapping
+ErrUniverseMismatch unless their operands are ComposableRelations.
+
+The exceptions are the predicate backed relations, which do not check
+membership. NewFunctionBinaryRelation and
+NewFunctionHeterogeneousRelation call their predicate with whatever
+elements they are given, and the lazy views constructed by Converse,
+Complement, Union, Intersection, Difference and Compose pass them on
+to their operands, so that they panic only if an operand does.
+
+Input which has not been validated, for example elements read from
+a request, should instead go through the checked operations
+TryAddRelation, TryRemoveRelation and TryContainsRelation, which
+return the *UniverseError rather than panic. Every *UniverseError
+wraps ErrNotInUniverse:
+
+	if err := relation.TryAddRelation(b, x, y); errors.Is(err, relation.ErrNotInUniverse) {
+		// reject the input
+	}
+*/
+package relation
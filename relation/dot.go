@@ -0,0 +1,231 @@
+package relation
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Diagram Options {{{
+
+// DiagramOptions configures the drawing of a relation by WriteDOT and
+// WriteSVG. The zero value draws every pair of the relation, labeling
+// each element by fmt.Sprint.
+type DiagramOptions struct {
+	// Name is the name of the graph, "relation" if empty.
+	Name string
+
+	// Hasse draws the Hasse diagram of the relation: the pairs of its
+	// TransitiveReduction, less those of the form (x, x). For a partial
+	// order, x lies below y if x < y, and the lines are undirected.
+	Hasse bool
+
+	// Cluster, if not nil, is an Equivalence over the same universe as
+	// the relation; elements of the same equivalence class are drawn
+	// together.
+	Cluster AbstractInterface
+
+	// Label names each element, fmt.Sprint if nil.
+	Label func(set.Element) string
+}
+
+// --- }}}
+
+// --- Diagrams {{{
+
+// diagram is the graph to be drawn for a relation: its vertices are
+// sorted by label, so that drawings are reproducible.
+type diagram struct {
+	name   string
+	hasse  bool
+	labels []string
+
+	// edges lists the pairs (i, j) to draw, i ≠ j, in order
+	edges [][2]int
+
+	// loop[i] indicates that i is related to itself
+	loop []bool
+
+	// class[i] is the index of the equivalence class of i, or -1 if
+	// there is no Cluster, of which there are classes
+	class   []int
+	classes int
+}
+
+// newDiagram prepares b to be drawn according to opts, which may be
+// nil. If opts.Cluster is not an Equivalence over the universe of b,
+// a *Violation or *UniverseError is returned.
+func newDiagram(b AbstractInterface, opts *DiagramOptions) (*diagram, error) {
+	if opts == nil {
+		opts = &DiagramOptions{}
+	}
+
+	label := opts.Label
+	if label == nil {
+		label = func(e set.Element) string {
+			return fmt.Sprint(e)
+		}
+	}
+
+	g := graphOf(b)
+	if opts.Hasse {
+		g = g.reduceTransitive()
+	}
+
+	n := len(g.elems)
+
+	// order[k] is the vertex of g drawn k-th
+	order := make([]int, n)
+	labels := make([]string, n)
+	for i, e := range g.elems {
+		order[i] = i
+		labels[i] = label(e)
+	}
+
+	sort.SliceStable(order, func(k, l int) bool {
+		return labels[order[k]] < labels[order[l]]
+	})
+
+	position := make([]int, n)
+	for k, i := range order {
+		position[i] = k
+	}
+
+	d := &diagram{
+		name:   opts.Name,
+		hasse:  opts.Hasse,
+		labels: make([]string, n),
+		loop:   make([]bool, n),
+		class:  make([]int, n),
+	}
+
+	if d.name == "" {
+		d.name = "relation"
+	}
+
+	for k, i := range order {
+		d.labels[k] = labels[i]
+		d.class[k] = -1
+
+		for _, j := range g.succ[i] {
+			if i == j {
+				d.loop[k] = !opts.Hasse
+			} else {
+				d.edges = append(d.edges, [2]int{k, position[j]})
+			}
+		}
+	}
+
+	sort.Slice(d.edges, func(a, b int) bool {
+		if d.edges[a][0] != d.edges[b][0] {
+			return d.edges[a][0] < d.edges[b][0]
+		}
+		return d.edges[a][1] < d.edges[b][1]
+	})
+
+	if opts.Cluster != nil {
+		q, err := NewQuotient(opts.Cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		// classes are numbered in order of their first member
+		number := make(map[int]int)
+
+		for k, i := range order {
+			c, ok := q.class[g.elems[i]]
+			if !ok {
+				return nil, &UniverseError{Op: "Cluster", Element: g.elems[i], Position: 1}
+			}
+
+			if _, ok := number[c]; !ok {
+				number[c] = len(number)
+			}
+
+			d.class[k] = number[c]
+		}
+
+		d.classes = len(number)
+	}
+
+	return d, nil
+}
+
+// --- }}}
+
+// --- DOT {{{
+
+// WriteDOT writes a description of b in the DOT language of Graphviz
+// to w, according to opts, which may be nil. For example,
+//
+//	relation.WriteDOT(os.Stdout, divides, &relation.DiagramOptions{Hasse: true})
+//
+// and then render with "dot -Tpng". Each class of opts.Cluster is
+// drawn as a subgraph cluster.
+//
+// An error is returned if opts.Cluster is not an Equivalence over the
+// universe of b, or writing to w fails.
+func WriteDOT(w io.Writer, b AbstractInterface, opts *DiagramOptions) error {
+	d, err := newDiagram(b, opts)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "digraph %s {\n", dotQuote(d.name))
+
+	if d.hasse {
+		buf.WriteString("\trankdir=BT;\n")
+		buf.WriteString("\tedge [dir=none];\n")
+	}
+
+	node := func(indent string, k int) {
+		fmt.Fprintf(&buf, "%sn%d [label=%s];\n", indent, k, dotQuote(d.labels[k]))
+	}
+
+	if d.classes > 0 {
+		members := make([][]int, d.classes)
+		for k, c := range d.class {
+			members[c] = append(members[c], k)
+		}
+
+		for c, ks := range members {
+			fmt.Fprintf(&buf, "\tsubgraph cluster_%d {\n", c)
+			for _, k := range ks {
+				node("\t\t", k)
+			}
+			buf.WriteString("\t}\n")
+		}
+	} else {
+		for k := range d.labels {
+			node("\t", k)
+		}
+	}
+
+	for k, loop := range d.loop {
+		if loop {
+			fmt.Fprintf(&buf, "\tn%d -> n%d;\n", k, k)
+		}
+	}
+
+	for _, e := range d.edges {
+		fmt.Fprintf(&buf, "\tn%d -> n%d;\n", e[0], e[1])
+	}
+
+	buf.WriteString("}\n")
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// dotQuote quotes s as a DOT string.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// --- }}}
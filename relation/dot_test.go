@@ -0,0 +1,84 @@
+package relation_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+func TestWriteDOT(t *testing.T) {
+	d := divides(1, 2, 3, 6)
+
+	var buf bytes.Buffer
+	if err := relation.WriteDOT(&buf, d, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// every pair of divisibility, including the four loops
+	if got := strings.Count(buf.String(), "->"); got != 9 {
+		t.Errorf("Expected 9 pairs to be drawn, got %d:\n%s", got, buf.String())
+	}
+
+	buf.Reset()
+	if err := relation.WriteDOT(&buf, d, &relation.DiagramOptions{Name: "divides", Hasse: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `digraph "divides" {
+	rankdir=BT;
+	edge [dir=none];
+	n0 [label="1"];
+	n1 [label="2"];
+	n2 [label="3"];
+	n3 [label="6"];
+	n0 -> n1;
+	n0 -> n2;
+	n1 -> n3;
+	n2 -> n3;
+}
+`
+
+	if buf.String() != expected {
+		t.Errorf("Expected the Hasse diagram\n%s\ngot\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteDOTCluster(t *testing.T) {
+	b := relation.New(set.WithElements(1, 2, 3, 4))
+	b.AddRelation(1, 3)
+
+	parity := relation.NewFunctionBinaryRelation(b.Universe(), func(x, y set.Element) bool {
+		return x.(int)%2 == y.(int)%2
+	})
+
+	var buf bytes.Buffer
+	if err := relation.WriteDOT(&buf, b, &relation.DiagramOptions{Cluster: parity}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), "subgraph cluster_0 {\n\t\tn0 [label=\"1\"];\n\t\tn2 [label=\"3\"];\n\t}") {
+		t.Errorf("Expected 1 and 3 to be clustered, got\n%s", buf.String())
+	}
+
+	var v *relation.Violation
+	if err := relation.WriteDOT(&buf, b, &relation.DiagramOptions{Cluster: b}); !errors.As(err, &v) {
+		t.Errorf("Expected clustering by a non-equivalence to fail, got %v", err)
+	}
+}
+
+func TestWriteDOTQuoting(t *testing.T) {
+	b := relation.New(set.WithElements(`say "hi"`))
+
+	var buf bytes.Buffer
+	if err := relation.WriteDOT(&buf, b, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), `[label="say \"hi\""]`) {
+		t.Errorf("Expected the label to be escaped, got\n%s", buf.String())
+	}
+}
@@ -0,0 +1,367 @@
+package relation
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Element Encoding {{{
+
+// A Formatter encodes an element as text.
+type Formatter func(set.Element) string
+
+// A Parser decodes an element from text, the inverse of a Formatter.
+type Parser func(string) (set.Element, error)
+
+// formatter defaults format to fmt.Sprint
+func formatter(format Formatter) Formatter {
+	if format != nil {
+		return format
+	}
+
+	return func(e set.Element) string {
+		return fmt.Sprint(e)
+	}
+}
+
+// parser defaults parse to the identity, so that elements are strings
+func parser(parse Parser) Parser {
+	if parse != nil {
+		return parse
+	}
+
+	return func(s string) (set.Element, error) {
+		return s, nil
+	}
+}
+
+// sortedGraph takes a snapshot of b whose vertices are ordered by
+// their formatted text, so that output is reproducible, and returns
+// that text.
+func sortedGraph(b AbstractInterface, format Formatter) (*graph, []string) {
+	g := graphOf(b)
+	n := len(g.elems)
+
+	// order[k] is the vertex of g which is k-th by text
+	order := make([]int, n)
+	labels := make([]string, n)
+	for i, e := range g.elems {
+		order[i] = i
+		labels[i] = format(e)
+	}
+
+	sort.SliceStable(order, func(k, l int) bool {
+		return labels[order[k]] < labels[order[l]]
+	})
+
+	elems := make([]set.Element, n)
+	text := make([]string, n)
+	for k, i := range order {
+		elems[k] = g.elems[i]
+		text[k] = labels[i]
+	}
+
+	h := newGraph(elems)
+	for k, i := range order {
+		for _, j := range g.succ[i] {
+			h.rows[k].add(h.index[g.elems[j]])
+		}
+	}
+
+	h.reindex()
+	return h, text
+}
+
+// readError wraps err, as reported while reading format from cr, as a
+// *FormatError, recovering the line of a *csv.ParseError.
+func readError(format string, cr *csv.Reader, err error) *FormatError {
+	var pe *csv.ParseError
+	if errors.As(err, &pe) {
+		return &FormatError{Format: format, Line: pe.Line, Err: pe.Err}
+	}
+
+	line, _ := cr.FieldPos(0)
+	return &FormatError{Format: format, Line: line, Err: err}
+}
+
+// --- }}}
+
+// --- Edge Lists {{{
+
+// WriteEdgeList writes the pairs of b to w as CSV, one pair "x,y" to a
+// line, with each element encoded by format, fmt.Sprint if nil. The
+// pairs are sorted by their text.
+func WriteEdgeList(w io.Writer, b AbstractInterface, format Formatter) error {
+	g, text := sortedGraph(b, formatter(format))
+
+	cw := csv.NewWriter(w)
+
+	for i := range g.elems {
+		for _, j := range g.succ[i] {
+			if err := cw.Write([]string{text[i], text[j]}); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadEdgeList reads a relation over universe from the CSV edge list
+// r, as written by WriteEdgeList. Each record must have two fields, x
+// and y, which are decoded by parse, or kept as strings if parse is
+// nil; lines beginning with '#' are ignored.
+//
+// A *FormatError is returned if the input is malformed, or names an
+// element not contained in universe, in which case it wraps a
+// *UniverseError.
+func ReadEdgeList(r io.Reader, universe set.Interface, parse Parser) (Interface, error) {
+	parse = parser(parse)
+
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 2
+
+	b := New(universe)
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return b, nil
+		}
+		if err != nil {
+			return nil, readError("edge list", cr, err)
+		}
+
+		x, err := parse(record[0])
+		if err != nil {
+			return nil, readError("edge list", cr, err)
+		}
+
+		y, err := parse(record[1])
+		if err != nil {
+			return nil, readError("edge list", cr, err)
+		}
+
+		if err := TryAddRelation(b, x, y); err != nil {
+			return nil, readError("edge list", cr, err)
+		}
+	}
+}
+
+// --- }}}
+
+// --- Adjacency Matrices {{{
+
+// WriteAdjacencyMatrix writes b to w as a CSV adjacency matrix. The
+// first record is a header naming the elements of the universe, after
+// an empty field; each following record names an element x, then gives
+// 1 in the column of y if xBy, and 0 otherwise. Elements are encoded
+// by format, fmt.Sprint if nil, and sorted by their text.
+func WriteAdjacencyMatrix(w io.Writer, b AbstractInterface, format Formatter) error {
+	g, text := sortedGraph(b, formatter(format))
+
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(append([]string{""}, text...)); err != nil {
+		return err
+	}
+
+	record := make([]string, len(text)+1)
+	for i := range g.elems {
+		record[0] = text[i]
+
+		for j := range g.elems {
+			if g.rows[i].has(j) {
+				record[j+1] = "1"
+			} else {
+				record[j+1] = "0"
+			}
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadAdjacencyMatrix reads a relation over universe from the CSV
+// adjacency matrix r, as written by WriteAdjacencyMatrix. Elements
+// are decoded by parse, or kept as strings if parse is nil. The matrix
+// need not name every element of the universe, nor give a row for
+// every column; elements which are not named are related to nothing.
+//
+// A *FormatError is returned if the input is malformed, an entry is
+// neither 0 nor 1, or an element is not contained in universe, in
+// which case it wraps a *UniverseError.
+func ReadAdjacencyMatrix(r io.Reader, universe set.Interface, parse Parser) (Interface, error) {
+	parse = parser(parse)
+
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+
+	fail := func(err error) (Interface, error) {
+		return nil, readError("adjacency matrix", cr, err)
+	}
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, &FormatError{Format: "adjacency matrix", Err: errors.New("missing header")}
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	columns := make([]set.Element, len(header)-1)
+	for j, field := range header[1:] {
+		y, err := parse(field)
+		if err != nil {
+			return fail(err)
+		}
+
+		if !universe.Contains(y) {
+			return fail(&UniverseError{Op: "ReadAdjacencyMatrix", Element: y, Position: 2})
+		}
+
+		columns[j] = y
+	}
+
+	b := New(universe)
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return b, nil
+		}
+		if err != nil {
+			return fail(err)
+		}
+
+		x, err := parse(record[0])
+		if err != nil {
+			return fail(err)
+		}
+
+		if !universe.Contains(x) {
+			return fail(&UniverseError{Op: "ReadAdjacencyMatrix", Element: x, Position: 1})
+		}
+
+		for j, entry := range record[1:] {
+			switch entry {
+			case "1":
+				b.AddRelation(x, columns[j])
+			case "0":
+			default:
+				return fail(fmt.Errorf("entry %q for (%v, %v) is neither 0 nor 1", entry, x, columns[j]))
+			}
+		}
+	}
+}
+
+// --- }}}
+
+// --- JSON {{{
+
+// jsonRelation is the JSON encoding of a relation
+type jsonRelation struct {
+	Universe []interface{}    `json:"universe"`
+	Pairs    [][2]interface{} `json:"pairs"`
+}
+
+// WriteJSON writes b to w as a JSON object carrying both its universe
+// and its pairs:
+//
+//	{"universe": [1, 2, 3], "pairs": [[1, 2], [2, 3]]}
+//
+// Elements are encoded by encoding/json, and sorted by fmt.Sprint.
+func WriteJSON(w io.Writer, b AbstractInterface) error {
+	g, _ := sortedGraph(b, formatter(nil))
+
+	v := jsonRelation{
+		Universe: make([]interface{}, len(g.elems)),
+		Pairs:    make([][2]interface{}, 0),
+	}
+
+	for i, x := range g.elems {
+		v.Universe[i] = x
+
+		for _, j := range g.succ[i] {
+			v.Pairs = append(v.Pairs, [2]interface{}{x, g.elems[j]})
+		}
+	}
+
+	return json.NewEncoder(w).Encode(v)
+}
+
+// ReadJSON reads a relation from the JSON object r, as written by
+// WriteJSON. Each element is decoded by encoding/json into an
+// interface{}, e.g., a number becomes a float64, and then converted by
+// convert; if convert is nil, elements are kept as decoded, and must
+// be strings, numbers, booleans or null.
+//
+// A *FormatError is returned if the input is malformed, an element
+// cannot be converted, or a pair names an element not contained in the
+// universe, in which case it wraps a *UniverseError.
+func ReadJSON(r io.Reader, convert func(interface{}) (set.Element, error)) (Interface, error) {
+	if convert == nil {
+		convert = func(v interface{}) (set.Element, error) {
+			switch v.(type) {
+			case []interface{}, map[string]interface{}:
+				return nil, fmt.Errorf("element %v is not a string, number, boolean or null", v)
+			}
+
+			return v, nil
+		}
+	}
+
+	fail := func(err error) (Interface, error) {
+		return nil, &FormatError{Format: "JSON", Err: err}
+	}
+
+	var v jsonRelation
+	if err := json.NewDecoder(r).Decode(&v); err != nil {
+		return fail(err)
+	}
+
+	universe := set.New()
+	for _, u := range v.Universe {
+		e, err := convert(u)
+		if err != nil {
+			return fail(err)
+		}
+
+		universe.Add(e)
+	}
+
+	b := New(universe)
+
+	for _, p := range v.Pairs {
+		x, err := convert(p[0])
+		if err != nil {
+			return fail(err)
+		}
+
+		y, err := convert(p[1])
+		if err != nil {
+			return fail(err)
+		}
+
+		if err := TryAddRelation(b, x, y); err != nil {
+			return fail(err)
+		}
+	}
+
+	return b, nil
+}
+
+// --- }}}
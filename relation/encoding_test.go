@@ -0,0 +1,132 @@
+package relation_test
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+func parseInt(s string) (set.Element, error) {
+	return strconv.Atoi(s)
+}
+
+func TestEdgeList(t *testing.T) {
+	d := divides(1, 2, 3, 6)
+
+	var buf bytes.Buffer
+	if err := relation.WriteEdgeList(&buf, d, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "1,1\n1,2\n1,3\n1,6\n2,2\n2,6\n3,3\n3,6\n6,6\n"
+	if buf.String() != expected {
+		t.Errorf("Expected the edge list\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	b, err := relation.ReadEdgeList(&buf, d.Universe(), parseInt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !equal(b, d) {
+		t.Errorf("Expected the edge list to read back as divisibility")
+	}
+
+	in := "# comment\n1,2\n2,7\n"
+	_, err = relation.ReadEdgeList(strings.NewReader(in), d.Universe(), parseInt)
+
+	var fe *relation.FormatError
+	if !errors.As(err, &fe) || fe.Line != 3 || !errors.Is(err, relation.ErrNotInUniverse) {
+		t.Errorf("Expected 7 on line 3 to be reported as not in the universe, got %v", err)
+	}
+
+	if _, err := relation.ReadEdgeList(strings.NewReader("1,2,3\n"), d.Universe(), parseInt); !errors.As(err, &fe) || fe.Line != 1 {
+		t.Errorf("Expected a record of three fields to be refused, got %v", err)
+	}
+
+	if _, err := relation.ReadEdgeList(strings.NewReader("1,x\n"), d.Universe(), parseInt); err == nil {
+		t.Errorf("Expected x not to parse")
+	}
+}
+
+func TestAdjacencyMatrix(t *testing.T) {
+	d := divides(1, 2, 4)
+
+	var buf bytes.Buffer
+	if err := relation.WriteAdjacencyMatrix(&buf, d, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := ",1,2,4\n1,1,1,1\n2,0,1,1\n4,0,0,1\n"
+	if buf.String() != expected {
+		t.Errorf("Expected the matrix\n%s\ngot\n%s", expected, buf.String())
+	}
+
+	b, err := relation.ReadAdjacencyMatrix(&buf, d.Universe(), parseInt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !equal(b, d) {
+		t.Errorf("Expected the matrix to read back as divisibility")
+	}
+
+	words := set.WithElements("a", "b")
+
+	b, err = relation.ReadAdjacencyMatrix(strings.NewReader(",b\na,1\n"), words, nil)
+	if err != nil || !b.ContainsRelation("a", "b") || b.ContainsRelation("b", "a") {
+		t.Errorf("Expected a partial matrix to relate a to b only, got %v", err)
+	}
+
+	for _, in := range []string{
+		",a,c\na,0,0\n",
+		",a,b\nc,0,0\n",
+		",a,b\na,0,2\n",
+		",a,b\na,0\n",
+		"",
+	} {
+		if _, err := relation.ReadAdjacencyMatrix(strings.NewReader(in), words, nil); err == nil {
+			t.Errorf("Expected %q to be refused", in)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	b := relation.New(set.WithElements("a", "b", "c"))
+	b.AddRelation("a", "b")
+	b.AddRelation("c", "c")
+
+	var buf bytes.Buffer
+	if err := relation.WriteJSON(&buf, b); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"universe":["a","b","c"],"pairs":[["a","b"],["c","c"]]}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, buf.String())
+	}
+
+	r, err := relation.ReadJSON(&buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !set.Equivalent(r.Universe(), b.Universe()) || !equal(r, b) {
+		t.Errorf("Expected the JSON to read back as b")
+	}
+
+	in := `{"universe": [1, 2], "pairs": [[1, 3]]}`
+	if _, err := relation.ReadJSON(strings.NewReader(in), nil); !errors.Is(err, relation.ErrNotInUniverse) {
+		t.Errorf("Expected 3 to be reported as not in the universe, got %v", err)
+	}
+
+	in = `{"universe": [[1]], "pairs": []}`
+	if _, err := relation.ReadJSON(strings.NewReader(in), nil); err == nil {
+		t.Errorf("Expected an array element to be refused")
+	}
+}
@@ -0,0 +1,170 @@
+package relation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Errors {{{
+
+// ErrNotInUniverse is reported when an element is not a member of
+// the universe over which a relation is defined. Every *UniverseError
+// wraps ErrNotInUniverse, so callers may test with errors.Is.
+var ErrNotInUniverse = errors.New("relation: element not in universe")
+
+// ErrUniverseMismatch is reported when relations which must share a
+// universe, for example the operands of Compose, do not. See
+// ComposableRelations.
+var ErrUniverseMismatch = errors.New("relation: relations are not defined over equivalent universes")
+
+// ErrNotPartition is reported when a set of sets is not a partition
+// of a universe: a collection of non-empty, pairwise disjoint sets
+// whose union is the universe. It is set.ErrNotPartition, so that the
+// errors of set.CheckPartition match it.
+var ErrNotPartition = set.ErrNotPartition
+
+// A UniverseError records an element given to an operation which is
+// not contained in the universe of the relation. For a heterogeneous
+// relation, an element at Position 1 is not contained in the domain,
+// and one at Position 2 is not contained in the codomain.
+type UniverseError struct {
+	// Op is the name of the operation, e.g., "AddRelation"
+	Op string
+
+	// Element is the offending element
+	Element set.Element
+
+	// Position is the argument position of Element, 1 or 2
+	Position int
+}
+
+// Error implements the error interface.
+func (e *UniverseError) Error() string {
+	return fmt.Sprintf("relation: %s: element %d (%v) is not contained in universe", e.Op, e.Position, e.Element)
+}
+
+// Unwrap returns ErrNotInUniverse.
+func (e *UniverseError) Unwrap() error {
+	return ErrNotInUniverse
+}
+
+// checkPair verifies that both e1 and e2 are members of the universe u,
+// reporting the first which is not as a *UniverseError.
+func checkPair(op string, u set.AbstractInterface, e1, e2 set.Element) error {
+	if !u.Contains(e1) {
+		return &UniverseError{Op: op, Element: e1, Position: 1}
+	}
+
+	if !u.Contains(e2) {
+		return &UniverseError{Op: op, Element: e2, Position: 2}
+	}
+
+	return nil
+}
+
+// CheckPair returns a *UniverseError if either e1 or e2 is not
+// contained in the universe of b, and nil otherwise.
+func CheckPair(b AbstractInterface, e1, e2 set.Element) error {
+	return checkPair("CheckPair", b.Universe(), e1, e2)
+}
+
+// A Violation is a counterexample to a property of a relation. It
+// records the elements which witness that the property does not hold,
+// e.g., the pair (x, y) such that xBy but not yBx for symmetry, or
+// the triple (x, y, z) such that xBy and yBz but not xBz for
+// transitivity.
+type Violation struct {
+	// Property is the name of the property which does not hold,
+	// e.g., "symmetric"
+	Property string
+
+	// Elements are the witnesses, in the order given by the
+	// documentation of the corresponding *Violation function
+	Elements []set.Element
+
+	// Reason explains how the Elements violate the Property
+	Reason string
+}
+
+// violate constructs a *Violation of property, witnessed by elements.
+func violate(property, reason string, elements ...set.Element) *Violation {
+	return &Violation{
+		Property: property,
+		Elements: elements,
+		Reason:   reason,
+	}
+}
+
+// Error implements the error interface.
+func (v *Violation) Error() string {
+	return fmt.Sprintf("relation: not %s: %s", v.Property, v.Reason)
+}
+
+// A FormatError records invalid input to one of the readers, e.g.,
+// ReadEdgeList. It wraps the underlying error, which is a
+// *UniverseError if the input names an element not contained in the
+// universe.
+type FormatError struct {
+	// Format is the name of the format read, e.g., "edge list"
+	Format string
+
+	// Line is the line of the input at which the error was found, or
+	// 0 if it is not known
+	Line int
+
+	// Err is the underlying error
+	Err error
+}
+
+// Error implements the error interface.
+func (e *FormatError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("relation: reading %s: %v", e.Format, e.Err)
+	}
+
+	return fmt.Sprintf("relation: reading %s: line %d: %v", e.Format, e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// --- }}}
+
+// --- Checked Operations {{{
+
+// TryAddRelation is the non-panicking form of b.AddRelation(e1, e2).
+// The relation is left unmodified if an error is returned.
+func TryAddRelation(b Interface, e1, e2 set.Element) error {
+	if err := checkPair("AddRelation", b.Universe(), e1, e2); err != nil {
+		return err
+	}
+
+	b.AddRelation(e1, e2)
+	return nil
+}
+
+// TryRemoveRelation is the non-panicking form of b.RemoveRelation(e1, e2).
+// The relation is left unmodified if an error is returned.
+func TryRemoveRelation(b Interface, e1, e2 set.Element) error {
+	if err := checkPair("RemoveRelation", b.Universe(), e1, e2); err != nil {
+		return err
+	}
+
+	b.RemoveRelation(e1, e2)
+	return nil
+}
+
+// TryContainsRelation is the non-panicking form of b.ContainsRelation(e1, e2).
+func TryContainsRelation(b AbstractInterface, e1, e2 set.Element) (bool, error) {
+	if err := checkPair("ContainsRelation", b.Universe(), e1, e2); err != nil {
+		return false, err
+	}
+
+	return b.ContainsRelation(e1, e2), nil
+}
+
+// --- }}}
@@ -0,0 +1,346 @@
+package relation
+
+import (
+	"fmt"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Types {{{
+
+// A Function is a heterogeneous relation which relates every element
+// of its domain to exactly one element of its codomain, f: X → Y.
+type Function interface {
+	HeterogeneousAbstractInterface
+
+	// Apply returns f(x), the element of the codomain to which x is
+	// related. Apply panics with a *UniverseError if x is not
+	// contained in the domain.
+	Apply(x set.Element) set.Element
+}
+
+// --- }}}
+
+// --- Finite Function Implementation {{{
+
+// NewFunction constructs the function from domain to codomain which
+// maps x to mapping[x]. The mapping is copied.
+//
+// A *UniverseError is returned if a key of mapping is not contained in
+// the domain, or a value is not contained in the codomain. A *Violation
+// is returned if some element of the domain is not mapped.
+func NewFunction(domain, codomain set.Interface, mapping map[set.Element]set.Element) (Function, error) {
+	f := &finiteFunction{
+		domain:   domain,
+		codomain: codomain,
+		mapping:  make(map[set.Element]set.Element, len(mapping)),
+	}
+
+	for x, y := range mapping {
+		if err := f.check("NewFunction", x, y); err != nil {
+			return nil, err
+		}
+
+		f.mapping[x] = y
+	}
+
+	for _, x := range domain.Elements() {
+		if _, ok := f.mapping[x]; !ok {
+			return nil, violate("a function", fmt.Sprintf("%v is not mapped", x), x)
+		}
+	}
+
+	return f, nil
+}
+
+// finiteFunction is a map backed function from domain to codomain
+type finiteFunction struct {
+	domain, codomain set.Interface
+	mapping          map[set.Element]set.Element
+}
+
+// check verifies that x is in the domain and y in the codomain.
+func (f *finiteFunction) check(op string, x, y set.Element) error {
+	if !f.domain.Contains(x) {
+		return &UniverseError{Op: op, Element: x, Position: 1}
+	}
+
+	if !f.codomain.Contains(y) {
+		return &UniverseError{Op: op, Element: y, Position: 2}
+	}
+
+	return nil
+}
+
+// Domain returns the set of arguments of the function, X.
+func (f *finiteFunction) Domain() set.Interface {
+	return f.domain
+}
+
+// Codomain returns the set of possible values of the function, Y.
+func (f *finiteFunction) Codomain() set.Interface {
+	return f.codomain
+}
+
+// ContainsRelation determines whether f(x) = y.
+//
+// ContainsRelation panics with a *UniverseError if x is not contained
+// in the domain, or y in the codomain.
+func (f *finiteFunction) ContainsRelation(x, y set.Element) bool {
+	if err := f.check("ContainsRelation", x, y); err != nil {
+		panic(err)
+	}
+
+	return f.mapping[x] == y
+}
+
+// Apply returns f(x).
+//
+// Apply panics with a *UniverseError if x is not contained in the domain.
+func (f *finiteFunction) Apply(x set.Element) set.Element {
+	y, ok := f.mapping[x]
+	if !ok {
+		panic(&UniverseError{Op: "Apply", Element: x, Position: 1})
+	}
+
+	return y
+}
+
+// --- }}}
+
+// --- Functions from Relations {{{
+
+// IsFunction checks that r relates every x ∈ Domain() to exactly one
+// y ∈ Codomain().
+func IsFunction(r HeterogeneousAbstractInterface) bool {
+	return FunctionViolation(r) == nil
+}
+
+// FunctionViolation returns an element x related to no element, or
+// a triple (x, y1, y2) such that x R y1 and x R y2 with y1 ≠ y2, or
+// nil if r IsFunction.
+func FunctionViolation(r HeterogeneousAbstractInterface) *Violation {
+	_, v := functionOf(r)
+	return v
+}
+
+// FunctionOf constructs the Function whose graph is r. If r is not a
+// function, the *Violation is returned as the error.
+func FunctionOf(r HeterogeneousAbstractInterface) (Function, error) {
+	f, v := functionOf(r)
+	if v != nil {
+		return nil, v
+	}
+
+	return f, nil
+}
+
+// functionOf constructs the Function whose graph is r, or reports why
+// there is none.
+func functionOf(r HeterogeneousAbstractInterface) (*finiteFunction, *Violation) {
+	if f, ok := r.(*finiteFunction); ok {
+		return f, nil
+	}
+
+	f := &finiteFunction{
+		domain:   r.Domain(),
+		codomain: r.Codomain(),
+		mapping:  make(map[set.Element]set.Element),
+	}
+
+	codomain := r.Codomain().Elements()
+
+	for _, x := range r.Domain().Elements() {
+		for _, y := range codomain {
+			if !r.ContainsRelation(x, y) {
+				continue
+			}
+
+			if z, ok := f.mapping[x]; ok {
+				return nil, violate("a function", fmt.Sprintf("%v is related to both %v and %v", x, z, y), x, z, y)
+			}
+
+			f.mapping[x] = y
+		}
+
+		if _, ok := f.mapping[x]; !ok {
+			return nil, violate("a function", fmt.Sprintf("%v is related to no element", x), x)
+		}
+	}
+
+	return f, nil
+}
+
+// --- }}}
+
+// --- Properties of Functions {{{
+
+// Injective checks that f maps distinct elements to distinct elements:
+//
+//	f(x) = f(y) ⇒ x = y for any x, y ∈ Domain()
+func Injective(f Function) bool {
+	return InjectiveViolation(f) == nil
+}
+
+// InjectiveViolation returns a pair (x, y) of distinct elements such
+// that f(x) = f(y), or nil if f is Injective.
+func InjectiveViolation(f Function) *Violation {
+	preimage := make(map[set.Element]set.Element)
+
+	for _, x := range f.Domain().Elements() {
+		fx := f.Apply(x)
+
+		if y, ok := preimage[fx]; ok {
+			return violate("injective", fmt.Sprintf("f(%v) = f(%v) = %v", y, x, fx), y, x)
+		}
+
+		preimage[fx] = x
+	}
+
+	return nil
+}
+
+// Surjective checks that f maps onto its codomain:
+//
+//	for any y ∈ Codomain(), there exists x ∈ Domain() with f(x) = y
+func Surjective(f Function) bool {
+	return SurjectiveViolation(f) == nil
+}
+
+// SurjectiveViolation returns an element y of the codomain such that
+// f(x) ≠ y for every x, or nil if f is Surjective.
+func SurjectiveViolation(f Function) *Violation {
+	image := make(map[set.Element]bool)
+	for _, x := range f.Domain().Elements() {
+		image[f.Apply(x)] = true
+	}
+
+	for _, y := range f.Codomain().Elements() {
+		if !image[y] {
+			return violate("surjective", fmt.Sprintf("no x has f(x) = %v", y), y)
+		}
+	}
+
+	return nil
+}
+
+// Bijective checks that f is Injective and Surjective.
+func Bijective(f Function) bool {
+	return BijectiveViolation(f) == nil
+}
+
+// BijectiveViolation returns the first counterexample to f being
+// Injective and Surjective, or nil if f is Bijective.
+func BijectiveViolation(f Function) *Violation {
+	if v := InjectiveViolation(f); v != nil {
+		return v
+	}
+
+	return SurjectiveViolation(f)
+}
+
+// --- }}}
+
+// --- Operations on Functions {{{
+
+// Inverse constructs the inverse of the bijection f, f⁻¹: Y → X, such
+// that f⁻¹(f(x)) = x. If f is not Bijective, the *Violation is
+// returned as the error.
+func Inverse(f Function) (Function, error) {
+	if v := BijectiveViolation(f); v != nil {
+		return nil, v
+	}
+
+	inverse := &finiteFunction{
+		domain:   f.Codomain(),
+		codomain: f.Domain(),
+		mapping:  make(map[set.Element]set.Element),
+	}
+
+	for _, x := range f.Domain().Elements() {
+		inverse.mapping[f.Apply(x)] = x
+	}
+
+	return inverse, nil
+}
+
+// ComposeFunctions constructs g ∘ f: X → Z, mapping x to g(f(x)), for
+// f: X → Y and g: Y → Z. Note the order: f is applied first, as in
+// Compose.
+//
+// ComposeFunctions panics with an error wrapping ErrUniverseMismatch
+// if the codomain of f is not a subset of the domain of g.
+func ComposeFunctions(f, g Function) Function {
+	if !set.IsSubset(f.Codomain(), g.Domain()) {
+		panic(fmt.Errorf("relation: ComposeFunctions: %w", ErrUniverseMismatch))
+	}
+
+	h := &finiteFunction{
+		domain:   f.Domain(),
+		codomain: g.Codomain(),
+		mapping:  make(map[set.Element]set.Element),
+	}
+
+	for _, x := range f.Domain().Elements() {
+		h.mapping[x] = g.Apply(f.Apply(x))
+	}
+
+	return h
+}
+
+// Kernel constructs the kernel of f, the equivalence relation over
+// its domain which relates elements with the same image:
+//
+//	x ~ y ⇔ f(x) = f(y)
+//
+// The equivalence classes of the kernel are the fibers of f.
+func Kernel(f Function) AbstractInterface {
+	return NewFunctionBinaryRelation(f.Domain(), func(x, y set.Element) bool {
+		return f.Apply(x) == f.Apply(y)
+	})
+}
+
+// Functions calls fn with each function from domain to codomain, of
+// which there are |codomain|^|domain|. Enumeration stops early if fn
+// returns false.
+func Functions(domain, codomain set.Interface, fn func(Function) bool) {
+	xs, ys := domain.Elements(), codomain.Elements()
+
+	if len(ys) == 0 && len(xs) > 0 {
+		return
+	}
+
+	// choice[i] is the index into ys of the value of xs[i]; we count
+	// through the choices as a number in base |ys|
+	choice := make([]int, len(xs))
+
+	for {
+		f := &finiteFunction{
+			domain:   domain,
+			codomain: codomain,
+			mapping:  make(map[set.Element]set.Element, len(xs)),
+		}
+
+		for i, x := range xs {
+			f.mapping[x] = ys[choice[i]]
+		}
+
+		if !fn(f) {
+			return
+		}
+
+		i := 0
+		for ; i < len(choice); i++ {
+			if choice[i]++; choice[i] < len(ys) {
+				break
+			}
+			choice[i] = 0
+		}
+
+		if i == len(choice) {
+			return
+		}
+	}
+}
+
+// --- }}}
@@ -0,0 +1,148 @@
+package relation_test
+
+import (
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+func TestFunctionProperties(t *testing.T) {
+	x := set.WithElements(1, 2, 3)
+	y := set.WithElements("a", "b", "c")
+
+	f, err := relation.NewFunction(x, y, map[set.Element]set.Element{1: "a", 2: "b", 3: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !relation.Injective(f) || !relation.Surjective(f) || !relation.Bijective(f) {
+		t.Fatalf("Expected f to be a bijection, got %v", relation.BijectiveViolation(f))
+	}
+
+	inverse, err := relation.Inverse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range x.Elements() {
+		if inverse.Apply(f.Apply(e)) != e {
+			t.Errorf("Expected f⁻¹(f(%v)) = %v", e, e)
+		}
+	}
+
+	g, _ := relation.NewFunction(x, y, map[set.Element]set.Element{1: "a", 2: "a", 3: "b"})
+
+	if v := relation.InjectiveViolation(g); v == nil || len(v.Elements) != 2 {
+		t.Errorf("Expected 1 and 2 to witness that g is not injective, got %v", v)
+	}
+
+	if v := relation.SurjectiveViolation(g); v == nil || v.Elements[0] != "c" {
+		t.Errorf("Expected c to witness that g is not surjective, got %v", v)
+	}
+
+	if _, err := relation.Inverse(g); err == nil {
+		t.Errorf("Expected g to have no inverse")
+	}
+
+	if _, err := relation.NewFunction(x, y, map[set.Element]set.Element{1: "a"}); err == nil {
+		t.Errorf("Expected a partial mapping to not be a function")
+	}
+
+	if _, err := relation.NewFunction(x, y, map[set.Element]set.Element{1: "z", 2: "a", 3: "a"}); err == nil {
+		t.Errorf("Expected a value outside of the codomain to be rejected")
+	}
+}
+
+func TestFunctionOf(t *testing.T) {
+	square := relation.NewFunctionHeterogeneousRelation(set.WithElements(-2, -1, 0, 1, 2), set.WithElements(0, 1, 4), func(x, y set.Element) bool {
+		return x.(int)*x.(int) == y.(int)
+	})
+
+	f, err := relation.FunctionOf(square)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f.Apply(-2) != 4 {
+		t.Errorf("Expected (-2)² = 4, got %v", f.Apply(-2))
+	}
+
+	classes, err := relation.EquivalenceClasses(relation.Kernel(f))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if classes.Cardinality() != 3 || !classes.Contains(set.WithElements(-1, 1)) {
+		t.Errorf("Expected the kernel of squaring to identify ±x, got %s", classes)
+	}
+
+	root := relation.NewFunctionHeterogeneousRelation(set.WithElements(0, 1, 4), set.WithElements(-2, -1, 0, 1, 2), func(y, x set.Element) bool {
+		return x.(int)*x.(int) == y.(int)
+	})
+
+	if relation.IsFunction(root) {
+		t.Errorf("Expected the square root relation to not be a function")
+	}
+
+	if v := relation.FunctionViolation(root); v == nil || len(v.Elements) != 3 {
+		t.Errorf("Expected a triple (x, y1, y2) witnessing two roots, got %v", v)
+	}
+}
+
+func TestComposeFunctions(t *testing.T) {
+	x := set.WithElements(1, 2)
+	y := set.WithElements("a", "b")
+	z := set.WithElements(true, false)
+
+	f, _ := relation.NewFunction(x, y, map[set.Element]set.Element{1: "a", 2: "b"})
+	g, _ := relation.NewFunction(y, z, map[set.Element]set.Element{"a": true, "b": false})
+
+	h := relation.ComposeFunctions(f, g)
+
+	if h.Domain() != x || h.Codomain() != z || h.Apply(1) != true || h.Apply(2) != false {
+		t.Errorf("Expected g ∘ f to map 1 to true and 2 to false")
+	}
+}
+
+func TestFunctions(t *testing.T) {
+	x := set.WithElements(1, 2, 3)
+	y := set.WithElements("a", "b")
+
+	count, bijections := 0, 0
+	relation.Functions(x, y, func(f relation.Function) bool {
+		count++
+		if relation.Surjective(f) {
+			bijections++
+		}
+		return true
+	})
+
+	if count != 8 {
+		t.Errorf("Expected 2³ = 8 functions, got %d", count)
+	}
+
+	if bijections != 6 {
+		t.Errorf("Expected 6 surjections from 3 elements onto 2, got %d", bijections)
+	}
+
+	count = 0
+	relation.Functions(set.New(), y, func(relation.Function) bool {
+		count++
+		return true
+	})
+
+	if count != 1 {
+		t.Errorf("Expected the single empty function, got %d", count)
+	}
+
+	count = 0
+	relation.Functions(x, set.New(), func(relation.Function) bool {
+		count++
+		return true
+	})
+
+	if count != 0 {
+		t.Errorf("Expected no functions into the empty set, got %d", count)
+	}
+}
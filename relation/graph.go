@@ -0,0 +1,425 @@
+package relation
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Bitset {{{
+
+// bitset is a fixed size set of the integers 0, ..., n-1,
+// packed into 64 bit words.
+type bitset []uint64
+
+// newBitset constructs an empty bitset able to hold 0, ..., n-1.
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (s bitset) add(i int) {
+	s[i/64] |= 1 << uint(i%64)
+}
+
+func (s bitset) remove(i int) {
+	s[i/64] &^= 1 << uint(i%64)
+}
+
+func (s bitset) has(i int) bool {
+	return s[i/64]&(1<<uint(i%64)) != 0
+}
+
+// union sets s to s ∪ t, and reports whether s changed.
+func (s bitset) union(t bitset) bool {
+	changed := false
+
+	for i := range s {
+		w := s[i] | t[i]
+		if w != s[i] {
+			s[i] = w
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// subset reports whether s ⊆ t.
+func (s bitset) subset(t bitset) bool {
+	for i := range s {
+		if s[i]&^t[i] != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// firstMissing returns the least i ∈ s \ t, or -1 if s ⊆ t.
+func (s bitset) firstMissing(t bitset) int {
+	for i := range s {
+		if w := s[i] &^ t[i]; w != 0 {
+			return i*64 + bits.TrailingZeros64(w)
+		}
+	}
+
+	return -1
+}
+
+// firstCommon returns the least i ∈ s ∩ t, or -1 if s ∩ t = ∅.
+func (s bitset) firstCommon(t bitset) int {
+	for i := range s {
+		if w := s[i] & t[i]; w != 0 {
+			return i*64 + bits.TrailingZeros64(w)
+		}
+	}
+
+	return -1
+}
+
+func (s bitset) count() int {
+	c := 0
+	for _, w := range s {
+		c += bits.OnesCount64(w)
+	}
+	return c
+}
+
+func (s bitset) clone() bitset {
+	c := make(bitset, len(s))
+	copy(c, s)
+	return c
+}
+
+// key encodes s as a string, for use as a map key.
+func (s bitset) key() string {
+	b := make([]byte, 8*len(s))
+	for i, w := range s {
+		binary.LittleEndian.PutUint64(b[8*i:], w)
+	}
+	return string(b)
+}
+
+// members lists the elements of s in increasing order.
+func (s bitset) members() []int {
+	m := make([]int, 0, s.count())
+
+	for i, w := range s {
+		for w != 0 {
+			m = append(m, i*64+bits.TrailingZeros64(w))
+			w &= w - 1
+		}
+	}
+
+	return m
+}
+
+// --- }}}
+
+// --- Graph {{{
+
+// graph is an indexed snapshot of a relation: the elements of its
+// universe are numbered 0, ..., n-1, and i → j iff elems[i] B elems[j].
+type graph struct {
+	elems []set.Element
+	index map[set.Element]int
+	rows  []bitset
+	succ  [][]int
+
+	// cols is the transpose of rows, computed on demand by columns
+	cols []bitset
+}
+
+// newGraph constructs a graph over elems with no edges.
+func newGraph(elems []set.Element) *graph {
+	n := len(elems)
+
+	g := &graph{
+		elems: elems,
+		index: make(map[set.Element]int, n),
+		rows:  make([]bitset, n),
+		succ:  make([][]int, n),
+	}
+
+	for i, e := range elems {
+		g.index[e] = i
+		g.rows[i] = newBitset(n)
+	}
+
+	return g
+}
+
+// graphOf takes a snapshot of the relation b.
+//
+// For a general AbstractInterface this costs n^2 calls to
+// ContainsRelation, where n = |Universe()|.
+func graphOf(b AbstractInterface) *graph {
+	switch r := b.(type) {
+	case *denseRelation:
+		return r.graph()
+	case *composition:
+		if g := r.graph(); g != nil {
+			return g
+		}
+	}
+
+	g := newGraph(b.Universe().Elements())
+
+	if br, ok := b.(*binaryRelation); ok && g.fill(br) {
+		return g
+	}
+
+	for i, x := range g.elems {
+		for j, y := range g.elems {
+			if b.ContainsRelation(x, y) {
+				g.rows[i].add(j)
+			}
+		}
+	}
+
+	g.reindex()
+	return g
+}
+
+// fill populates g from the map backing br, and reports whether
+// every related element could be indexed.
+func (g *graph) fill(br *binaryRelation) bool {
+	for e1, bucket := range br.relations {
+		i, ok := g.index[e1]
+		if !ok {
+			return false
+		}
+
+		for e2 := range bucket {
+			j, ok := g.index[e2]
+			if !ok {
+				return false
+			}
+
+			g.rows[i].add(j)
+		}
+	}
+
+	g.reindex()
+	return true
+}
+
+// align constructs a graph over the elements of h with the edges of g,
+// or returns nil if some element of h is not indexed by g.
+func (g *graph) align(h *graph) *graph {
+	perm := make([]int, len(h.elems))
+	same := len(g.elems) == len(h.elems)
+
+	for i, e := range h.elems {
+		j, ok := g.index[e]
+		if !ok {
+			return nil
+		}
+
+		perm[i] = j
+		same = same && i == j
+	}
+
+	if same {
+		return g
+	}
+
+	a := newGraph(h.elems)
+	for i := range a.rows {
+		for j := range a.rows {
+			if g.rows[perm[i]].has(perm[j]) {
+				a.rows[i].add(j)
+			}
+		}
+	}
+
+	a.reindex()
+	return a
+}
+
+// reindex recomputes the successor lists from the rows.
+func (g *graph) reindex() {
+	for i := range g.rows {
+		g.succ[i] = g.rows[i].members()
+	}
+	g.cols = nil
+}
+
+// columns returns the transpose of g's rows: columns()[j].has(i)
+// iff i → j.
+func (g *graph) columns() []bitset {
+	if g.cols != nil {
+		return g.cols
+	}
+
+	n := len(g.elems)
+	g.cols = make([]bitset, n)
+	for j := range g.cols {
+		g.cols[j] = newBitset(n)
+	}
+
+	for i, succ := range g.succ {
+		for _, j := range succ {
+			g.cols[j].add(i)
+		}
+	}
+
+	return g.cols
+}
+
+// findCycle returns the vertices of a cycle i0 → i1 → ... → ik → i0
+// in g, or nil if g is acyclic. A self loop is a cycle of length one.
+func (g *graph) findCycle() []int {
+	const (
+		white = iota
+		gray
+		black
+	)
+
+	n := len(g.elems)
+	color := make([]int, n)
+	parent := make([]int, n)
+
+	type frame struct{ v, next int }
+
+	for root := 0; root < n; root++ {
+		if color[root] != white {
+			continue
+		}
+
+		color[root] = gray
+		stack := []frame{{v: root}}
+
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+
+			if top.next == len(g.succ[top.v]) {
+				color[top.v] = black
+				stack = stack[:len(stack)-1]
+				continue
+			}
+
+			w := g.succ[top.v][top.next]
+			top.next++
+
+			switch color[w] {
+			case white:
+				color[w] = gray
+				parent[w] = top.v
+				stack = append(stack, frame{v: w})
+			case gray:
+				// back edge top.v → w closes the cycle w → ... → top.v
+				cycle := []int{top.v}
+				for v := top.v; v != w; {
+					v = parent[v]
+					cycle = append(cycle, v)
+				}
+
+				for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+					cycle[i], cycle[j] = cycle[j], cycle[i]
+				}
+
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
+// components computes the strongly connected components of g using
+// Tarjan's algorithm. The components are listed in reverse topological
+// order: if i → j for i and j in distinct components, then the
+// component of j precedes that of i. comp maps each vertex to the
+// position of its component.
+func (g *graph) components() (comps [][]int, comp []int) {
+	n := len(g.elems)
+
+	// index[v] is 0 until v is visited, and 1 + its visiting order after
+	index := make([]int, n)
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	comp = make([]int, n)
+
+	var stack []int
+	counter := 0
+
+	visit := func(v int) {
+		counter++
+		index[v], low[v] = counter, counter
+		stack = append(stack, v)
+		onStack[v] = true
+	}
+
+	type frame struct{ v, next int }
+
+	for root := 0; root < n; root++ {
+		if index[root] != 0 {
+			continue
+		}
+
+		visit(root)
+		calls := []frame{{v: root}}
+
+		for len(calls) > 0 {
+			top := &calls[len(calls)-1]
+			v := top.v
+
+			if top.next < len(g.succ[v]) {
+				w := g.succ[v][top.next]
+				top.next++
+
+				if index[w] == 0 {
+					visit(w)
+					calls = append(calls, frame{v: w})
+				} else if onStack[w] && index[w] < low[v] {
+					low[v] = index[w]
+				}
+
+				continue
+			}
+
+			// v is finished; it is the root of a component if it
+			// cannot reach anything visited before it
+			if low[v] == index[v] {
+				var c []int
+
+				for {
+					w := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[w] = false
+					comp[w] = len(comps)
+					c = append(c, w)
+
+					if w == v {
+						break
+					}
+				}
+
+				comps = append(comps, c)
+			}
+
+			calls = calls[:len(calls)-1]
+
+			if len(calls) > 0 {
+				if u := calls[len(calls)-1].v; low[v] < low[u] {
+					low[u] = low[v]
+				}
+			}
+		}
+	}
+
+	return comps, comp
+}
+
+// elements maps the vertices is back to the elements of the universe.
+func (g *graph) elements(is []int) []set.Element {
+	es := make([]set.Element, len(is))
+	for k, i := range is {
+		es[k] = g.elems[i]
+	}
+	return es
+}
+
+// --- }}}
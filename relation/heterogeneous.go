@@ -0,0 +1,279 @@
+package relation
+
+import (
+	"fmt"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Types {{{
+
+type (
+	// A HeterogeneousAbstractInterface is a binary relation from one
+	// set, its Domain, to another, its Codomain: a subset of
+	// Domain() × Codomain(). For example, "works on" relates employees
+	// to projects.
+	HeterogeneousAbstractInterface interface {
+		Domain() set.Interface
+		Codomain() set.Interface
+		ContainsRelation(set.Element, set.Element) bool
+	}
+
+	// A HeterogeneousInterface is a physical heterogeneous relation,
+	// constructed piecewise using the AddRelation function.
+	HeterogeneousInterface interface {
+		HeterogeneousAbstractInterface
+		AddRelation(set.Element, set.Element)
+		RemoveRelation(set.Element, set.Element)
+	}
+)
+
+// --- }}}
+
+// --- Heterogeneous Relation Implementation {{{
+
+// NewHeterogeneous constructs a new, empty relation from domain to
+// codomain.
+func NewHeterogeneous(domain, codomain set.Interface) HeterogeneousInterface {
+	return &heterogeneousRelation{
+		domain:    domain,
+		codomain:  codomain,
+		relations: make(map[set.Element]map[set.Element]bool),
+	}
+}
+
+// heterogeneousRelation is a map backed relation from domain to codomain
+type heterogeneousRelation struct {
+	domain, codomain set.Interface
+	relations        map[set.Element]map[set.Element]bool
+}
+
+// Domain returns the set of which the first elements of pairs are members.
+func (h *heterogeneousRelation) Domain() set.Interface {
+	return h.domain
+}
+
+// Codomain returns the set of which the second elements of pairs are members.
+func (h *heterogeneousRelation) Codomain() set.Interface {
+	return h.codomain
+}
+
+// check verifies that x is in the domain and y in the codomain.
+func (h *heterogeneousRelation) check(op string, x, y set.Element) {
+	if !h.domain.Contains(x) {
+		panic(&UniverseError{Op: op, Element: x, Position: 1})
+	}
+
+	if !h.codomain.Contains(y) {
+		panic(&UniverseError{Op: op, Element: y, Position: 2})
+	}
+}
+
+// AddRelation notes that x is related to y.
+//
+// AddRelation panics with a *UniverseError if x is not contained in
+// the domain, or y in the codomain.
+func (h *heterogeneousRelation) AddRelation(x, y set.Element) {
+	h.check("AddRelation", x, y)
+
+	bucket, exists := h.relations[x]
+	if !exists {
+		bucket = make(map[set.Element]bool)
+		h.relations[x] = bucket
+	}
+
+	bucket[y] = true
+}
+
+// RemoveRelation is the inverse operation of AddRelation.
+//
+// RemoveRelation panics with a *UniverseError if x is not contained in
+// the domain, or y in the codomain.
+func (h *heterogeneousRelation) RemoveRelation(x, y set.Element) {
+	h.check("RemoveRelation", x, y)
+
+	if bucket, exists := h.relations[x]; exists {
+		delete(bucket, y)
+	}
+}
+
+// ContainsRelation determines whether x is related to y.
+//
+// ContainsRelation panics with a *UniverseError if x is not contained
+// in the domain, or y in the codomain.
+func (h *heterogeneousRelation) ContainsRelation(x, y set.Element) bool {
+	h.check("ContainsRelation", x, y)
+
+	return h.relations[x][y]
+}
+
+// --- }}}
+
+// --- Function Based Heterogeneous Relation {{{
+
+type fnHeterogeneousRelation struct {
+	domain, codomain set.Interface
+	related          RelatedPredicate
+}
+
+// NewFunctionHeterogeneousRelation constructs a new relation from
+// domain to codomain defined by the RelatedPredicate fn. Like
+// NewFunctionBinaryRelation, it does not check membership.
+func NewFunctionHeterogeneousRelation(domain, codomain set.Interface, fn RelatedPredicate) HeterogeneousAbstractInterface {
+	return &fnHeterogeneousRelation{
+		domain:   domain,
+		codomain: codomain,
+		related:  fn,
+	}
+}
+
+// Domain returns the set of which the first elements of pairs are members.
+func (fh *fnHeterogeneousRelation) Domain() set.Interface {
+	return fh.domain
+}
+
+// Codomain returns the set of which the second elements of pairs are members.
+func (fh *fnHeterogeneousRelation) Codomain() set.Interface {
+	return fh.codomain
+}
+
+// ContainsRelation indicates whether x is in relation to y.
+func (fh *fnHeterogeneousRelation) ContainsRelation(x, y set.Element) bool {
+	return fh.related(x, y)
+}
+
+// --- }}}
+
+// --- Compatibility {{{
+
+// homogeneous presents a heterogeneous relation whose domain and
+// codomain are equivalent as an AbstractInterface
+type homogeneous struct {
+	HeterogeneousAbstractInterface
+}
+
+// Universe returns the domain, which is equivalent to the codomain.
+func (h homogeneous) Universe() set.Interface {
+	return h.Domain()
+}
+
+// heterogeneous presents an AbstractInterface as a heterogeneous
+// relation from its universe to itself
+type heterogeneous struct {
+	AbstractInterface
+}
+
+// Domain returns the universe.
+func (h heterogeneous) Domain() set.Interface {
+	return h.Universe()
+}
+
+// Codomain returns the universe.
+func (h heterogeneous) Codomain() set.Interface {
+	return h.Universe()
+}
+
+// Heterogeneous presents b as a relation from its universe to itself,
+// so that it may be given to the functions on heterogeneous relations.
+func Heterogeneous(b AbstractInterface) HeterogeneousAbstractInterface {
+	if h, ok := b.(homogeneous); ok {
+		return h.HeterogeneousAbstractInterface
+	}
+
+	return heterogeneous{b}
+}
+
+// Homogeneous presents r, a relation from a set to itself, as an
+// AbstractInterface over that set, so that it may be given to the
+// functions on (homogeneous) relations, e.g., Transitive.
+//
+// An error wrapping ErrUniverseMismatch is returned if the domain and
+// codomain of r are not equivalent.
+func Homogeneous(r HeterogeneousAbstractInterface) (AbstractInterface, error) {
+	if h, ok := r.(heterogeneous); ok {
+		return h.AbstractInterface, nil
+	}
+
+	if r.Domain() != r.Codomain() && !set.Equivalent(r.Domain(), r.Codomain()) {
+		return nil, fmt.Errorf("relation: Homogeneous: domain and codomain differ: %w", ErrUniverseMismatch)
+	}
+
+	return homogeneous{r}, nil
+}
+
+// --- }}}
+
+// --- Images {{{
+
+// Image constructs the image of s under r, R[s]: the y ∈ Codomain()
+// such that xRy for some x ∈ s.
+func Image(r HeterogeneousAbstractInterface, s set.Interface) set.Interface {
+	image := set.New()
+
+	if h, ok := r.(*heterogeneousRelation); ok {
+		for _, x := range s.Elements() {
+			for y := range h.relations[x] {
+				image.Add(y)
+			}
+		}
+
+		return image
+	}
+
+	codomain := r.Codomain().Elements()
+
+	for _, x := range s.Elements() {
+		for _, y := range codomain {
+			if r.ContainsRelation(x, y) {
+				image.Add(y)
+			}
+		}
+	}
+
+	return image
+}
+
+// Preimage constructs the preimage of t under r, R⁻¹[t]: the
+// x ∈ Domain() such that xRy for some y ∈ t.
+func Preimage(r HeterogeneousAbstractInterface, t set.Interface) set.Interface {
+	preimage := set.New()
+	ys := t.Elements()
+
+Domain:
+	for _, x := range r.Domain().Elements() {
+		for _, y := range ys {
+			if r.ContainsRelation(x, y) {
+				preimage.Add(x)
+				continue Domain
+			}
+		}
+	}
+
+	return preimage
+}
+
+// RestrictDomain constructs the restriction of r to the domain s,
+// which should be a subset of r.Domain(): {(x, y) ∈ R | x ∈ s}. The
+// restriction is lazy, it reflects later changes to r.
+func RestrictDomain(r HeterogeneousAbstractInterface, s set.Interface) HeterogeneousAbstractInterface {
+	return NewFunctionHeterogeneousRelation(s, r.Codomain(), func(x, y set.Element) bool {
+		return s.Contains(x) && r.ContainsRelation(x, y)
+	})
+}
+
+// RestrictRange constructs the restriction of r to the codomain t,
+// which should be a subset of r.Codomain(): {(x, y) ∈ R | y ∈ t}. The
+// restriction is lazy, it reflects later changes to r.
+func RestrictRange(r HeterogeneousAbstractInterface, t set.Interface) HeterogeneousAbstractInterface {
+	return NewFunctionHeterogeneousRelation(r.Domain(), t, func(x, y set.Element) bool {
+		return t.Contains(y) && r.ContainsRelation(x, y)
+	})
+}
+
+// Range constructs the set of elements to which some element is
+// related, R[Domain()].
+func Range(r HeterogeneousAbstractInterface) set.Interface {
+	return Image(r, r.Domain())
+}
+
+// --- }}}
@@ -0,0 +1,106 @@
+package relation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+func TestHeterogeneousBasicUsage(t *testing.T) {
+	employees := set.WithElements("ada", "alan", "grace")
+	projects := set.WithElements("compiler", "engine")
+
+	worksOn := relation.NewHeterogeneous(employees, projects)
+
+	if worksOn.Domain() != employees || worksOn.Codomain() != projects {
+		t.Errorf("Expected the domain and codomain given in construction")
+	}
+
+	worksOn.AddRelation("ada", "engine")
+	worksOn.AddRelation("grace", "compiler")
+	worksOn.AddRelation("alan", "engine")
+
+	if !worksOn.ContainsRelation("ada", "engine") || worksOn.ContainsRelation("ada", "compiler") {
+		t.Errorf("Expected ada to work on the engine, but not the compiler")
+	}
+
+	if image := relation.Image(worksOn, set.WithElements("ada", "grace")); !set.Equivalent(image, projects) {
+		t.Errorf("Expected ada and grace to work on every project, got %s", image)
+	}
+
+	if pre := relation.Preimage(worksOn, set.WithElements("engine")); !set.Equivalent(pre, set.WithElements("ada", "alan")) {
+		t.Errorf("Expected ada and alan to work on the engine, got %s", pre)
+	}
+
+	worksOn.RemoveRelation("alan", "engine")
+
+	if r := relation.Range(worksOn); !set.Equivalent(r, projects) {
+		t.Errorf("Expected every project to be worked on, got %s", r)
+	}
+
+	_, err := relation.Homogeneous(worksOn)
+	if !errors.Is(err, relation.ErrUniverseMismatch) {
+		t.Errorf("Expected a relation from employees to projects to not be homogeneous, got %v", err)
+	}
+
+	defer func() {
+		uerr, ok := recover().(*relation.UniverseError)
+		if !ok || uerr.Position != 2 {
+			t.Errorf("Expected a project outside of the codomain to panic at position 2, got %v", uerr)
+		}
+	}()
+
+	worksOn.AddRelation("ada", "ada")
+}
+
+func TestRestriction(t *testing.T) {
+	lessThan := relation.NewFunctionHeterogeneousRelation(numbers, numbers, func(x, y set.Element) bool {
+		return x.(int) < y.(int)
+	})
+
+	small := set.WithElements(0, 1, 2)
+	r := relation.RestrictDomain(lessThan, small)
+
+	if r.Domain() != small || r.ContainsRelation(5, 6) || !r.ContainsRelation(1, 6) {
+		t.Errorf("Expected the restriction to relate only 0, 1 and 2")
+	}
+
+	r = relation.RestrictRange(lessThan, small)
+
+	if r.Codomain() != small || r.ContainsRelation(0, 6) || !r.ContainsRelation(0, 2) {
+		t.Errorf("Expected the restriction to relate only to 0, 1 and 2")
+	}
+}
+
+func TestHomogeneousShims(t *testing.T) {
+	h := relation.Heterogeneous(lessEqual)
+
+	if h.Domain() != numbers || h.Codomain() != numbers {
+		t.Errorf("Expected the domain and codomain to be the universe")
+	}
+
+	if image := relation.Image(h, set.WithElements(98)); !set.Equivalent(image, set.WithElements(98, 99)) {
+		t.Errorf("Expected the image of {98} under ≤ to be {98, 99}, got %s", image)
+	}
+
+	b, err := relation.Homogeneous(h)
+	if err != nil || b != lessEqual {
+		t.Errorf("Expected Homogeneous to undo Heterogeneous, got %v (%v)", b, err)
+	}
+
+	u := set.WithElements(1, 2, 3)
+	r := relation.NewHeterogeneous(u, set.Clone(u))
+	r.AddRelation(1, 2)
+	r.AddRelation(2, 3)
+
+	b, err = relation.Homogeneous(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if relation.Transitive(b) || !relation.Irreflexive(b) {
+		t.Errorf("Expected the homogeneous relation to be irreflexive, but not transitive")
+	}
+}
@@ -0,0 +1,519 @@
+package relation
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Canonical Labeling {{{
+
+// An isomorphism from a relation R over X to a relation S over Y is a
+// bijection φ: X → Y such that xRy ⇔ φ(x) S φ(y). The relations are
+// then isomorphic: they have the same structure, and differ only in
+// the names of their elements.
+//
+// The functions in this section decide isomorphism by canonical
+// labeling: the elements of each relation are numbered 0, ..., n-1 in
+// a manner which depends only on the structure of the relation, so
+// that two relations are isomorphic iff their relabeled pairs are
+// identical. The labeling is found by the individualization-refinement
+// search of McKay's nauty:
+//
+//  1. the elements are colored by whether they are related to
+//     themselves, and the coloring is refined until elements of a
+//     color have, for each color, the same number of successors and
+//     of predecessors of that color;
+//  2. if some color is shared by several elements, each in turn is
+//     individualized, given a color of its own, and the search recurs;
+//  3. each leaf of the search, where every element has its own color,
+//     is a labeling, and the canonical labeling is that whose
+//     relabeled pairs are greatest.
+//
+// Two leaves with the same relabeled pairs reveal an automorphism,
+// which is used to prune the search: the children of a node which are
+// equivalent under the automorphisms found are explored only once, and
+// the search returns to the point of divergence on finding a leaf
+// equivalent to the first or greatest so far. Refinement alone
+// distinguishes the elements of most relations, and the search is
+// suitable for universes of hundreds of elements, though highly
+// regular relations may take longer.
+
+// canon is the state of a search for the canonical labeling of g
+type canon struct {
+	g *graph
+
+	// the successors and predecessors of each vertex which refinement
+	// considers: those of g, or of its complement if g is dense, which
+	// refines alike at less cost
+	succ, pred [][]int
+
+	// the first and greatest leaves: the elements individualized to
+	// reach them, their labelings and certificates
+	found               bool
+	firstPath, bestPath []int
+	firstLab, bestLab   []int
+	firstCert, bestCert string
+
+	// generators of the automorphism group, as permutations of the
+	// vertices of g
+	gens [][]int
+}
+
+// canonicalize computes the canonical labeling of the snapshot of b.
+func canonicalize(b AbstractInterface) *canon {
+	g := graphOf(b)
+	n := len(g.elems)
+
+	c := &canon{g: g, succ: g.succ, pred: make([][]int, n)}
+
+	edges := 0
+	for _, succ := range g.succ {
+		edges += len(succ)
+	}
+
+	if 2*edges > n*n {
+		c.succ = make([][]int, n)
+		for v := range c.succ {
+			for w := 0; w < n; w++ {
+				if !g.rows[v].has(w) {
+					c.succ[v] = append(c.succ[v], w)
+				}
+			}
+		}
+	}
+
+	color := make([]int, n)
+	for v, succ := range c.succ {
+		for _, w := range succ {
+			c.pred[w] = append(c.pred[w], v)
+		}
+
+		if g.rows[v].has(v) {
+			color[v] = 1
+		}
+	}
+
+	c.explore(c.refine(color), nil)
+	return c
+}
+
+// refine computes the coarsest equitable refinement of color: in which
+// vertices of the same color have, for each color, the same number of
+// successors and predecessors of that color. Colors are numbered
+// 0, ..., k-1 in an order which depends only on the structure of g and
+// the order of the colors given, so that the refinement is invariant
+// under isomorphism.
+func (c *canon) refine(color []int) []int {
+	n := len(color)
+
+	count := make(map[int]bool)
+	for _, k := range color {
+		count[k] = true
+	}
+	colors := len(count)
+
+	keys := make([]string, n)
+	order := make([]int, n)
+	var sig []int
+
+	for {
+		for v := 0; v < n; v++ {
+			// the signature of v is its color, then the sorted colors
+			// of its successors, then of its predecessors
+			sig = append(sig[:0], color[v])
+			sig = appendColors(sig, c.succ[v], color)
+			sig = appendColors(sig, c.pred[v], color)
+			keys[v] = encodeInts(sig)
+			order[v] = v
+		}
+
+		sort.Slice(order, func(i, j int) bool {
+			return keys[order[i]] < keys[order[j]]
+		})
+
+		next := make([]int, n)
+		k := -1
+		for i, v := range order {
+			if i == 0 || keys[v] != keys[order[i-1]] {
+				k++
+			}
+			next[v] = k
+		}
+
+		if k+1 == colors {
+			return next
+		}
+
+		color, colors = next, k+1
+	}
+}
+
+// appendColors appends to sig the number of vertices vs, then their
+// colors, sorted.
+func appendColors(sig, vs, color []int) []int {
+	sig = append(sig, len(vs))
+	start := len(sig)
+
+	for _, v := range vs {
+		sig = append(sig, color[v])
+	}
+
+	sort.Ints(sig[start:])
+	return sig
+}
+
+// encodeInts encodes the non-negative integers is as a string, such
+// that strings compare as the sequences do.
+func encodeInts(is []int) string {
+	buf := make([]byte, 4*len(is))
+	for i, x := range is {
+		binary.BigEndian.PutUint32(buf[4*i:], uint32(x))
+	}
+	return string(buf)
+}
+
+// explore searches the subtree of the node reached by individualizing
+// the vertices of path, whose refined coloring is color. It returns
+// the depth to which the search should return: len(path) on
+// completion, or less on finding an automorphism.
+func (c *canon) explore(color []int, path []int) int {
+	depth := len(path)
+
+	cell := target(color)
+	if cell == nil {
+		return c.leaf(color, path)
+	}
+
+	var explored []int
+
+	for _, w := range cell {
+		if c.equivalent(w, explored, path) {
+			continue
+		}
+
+		explored = append(explored, w)
+
+		// w precedes the other vertices of its color
+		child := make([]int, len(color))
+		for v, k := range color {
+			child[v] = 2*k + 1
+		}
+		child[w] = 2 * color[w]
+
+		if jump := c.explore(c.refine(child), append(path[:depth:depth], w)); jump < depth {
+			return jump
+		}
+	}
+
+	return depth
+}
+
+// target returns the vertices of the first color shared by several,
+// or nil if every vertex has its own color.
+func target(color []int) []int {
+	count := make([]int, len(color))
+	for _, k := range color {
+		count[k]++
+	}
+
+	for k, m := range count {
+		if m > 1 {
+			cell := make([]int, 0, m)
+			for v, l := range color {
+				if l == k {
+					cell = append(cell, v)
+				}
+			}
+			return cell
+		}
+	}
+
+	return nil
+}
+
+// equivalent reports whether w is in the orbit of some explored
+// vertex, under the automorphisms found which fix path.
+func (c *canon) equivalent(w int, explored, path []int) bool {
+	if len(explored) == 0 {
+		return false
+	}
+
+	n := len(c.g.elems)
+	parent := make([]int, n)
+	for v := range parent {
+		parent[v] = v
+	}
+
+	find := func(v int) int {
+		for parent[v] != v {
+			parent[v] = parent[parent[v]]
+			v = parent[v]
+		}
+		return v
+	}
+
+Generators:
+	for _, gen := range c.gens {
+		for _, v := range path {
+			if gen[v] != v {
+				continue Generators
+			}
+		}
+
+		for v, u := range gen {
+			parent[find(v)] = find(u)
+		}
+	}
+
+	for _, e := range explored {
+		if find(e) == find(w) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// leaf considers the labeling of the discrete coloring lab, reached by
+// individualizing path.
+func (c *canon) leaf(lab []int, path []int) int {
+	cert := c.certificate(lab)
+	path = append([]int(nil), path...)
+
+	switch {
+	case !c.found:
+		c.found = true
+		c.firstPath, c.firstLab, c.firstCert = path, lab, cert
+		c.bestPath, c.bestLab, c.bestCert = path, lab, cert
+	case cert == c.firstCert:
+		c.automorphism(c.firstLab, lab)
+		return commonPrefix(path, c.firstPath)
+	case cert == c.bestCert:
+		c.automorphism(c.bestLab, lab)
+		return commonPrefix(path, c.bestPath)
+	case cert > c.bestCert:
+		c.bestPath, c.bestLab, c.bestCert = path, lab, cert
+	}
+
+	return len(path)
+}
+
+// certificate encodes the pairs of g relabeled by lab.
+func (c *canon) certificate(lab []int) string {
+	n := len(lab)
+
+	inv := make([]int, n)
+	for v, l := range lab {
+		inv[l] = v
+	}
+
+	buf := make([]byte, binary.MaxVarintLen64+(n*n+7)/8)
+	k := binary.PutUvarint(buf, uint64(n))
+
+	for i, v := range inv {
+		for _, w := range c.g.succ[v] {
+			bit := i*n + lab[w]
+			buf[k+bit/8] |= 1 << uint(7-bit%8)
+		}
+	}
+
+	return string(buf)
+}
+
+// automorphism records the automorphism mapping each vertex labeled by
+// b to the vertex with the same label in a.
+func (c *canon) automorphism(a, b []int) {
+	inv := make([]int, len(a))
+	for v, l := range a {
+		inv[l] = v
+	}
+
+	gen := make([]int, len(b))
+	identity := true
+	for v, l := range b {
+		gen[v] = inv[l]
+		identity = identity && gen[v] == v
+	}
+
+	if !identity {
+		c.gens = append(c.gens, gen)
+	}
+}
+
+// commonPrefix returns the length of the common prefix of p and q.
+func commonPrefix(p, q []int) int {
+	k := 0
+	for k < len(p) && k < len(q) && p[k] == q[k] {
+		k++
+	}
+	return k
+}
+
+// CanonicalLabeling numbers the elements of the universe of b
+// 0, ..., n-1, such that relations are isomorphic iff the pairs of
+// their labels are the same.
+func CanonicalLabeling(b AbstractInterface) map[set.Element]int {
+	c := canonicalize(b)
+
+	labels := make(map[set.Element]int, len(c.bestLab))
+	for v, l := range c.bestLab {
+		labels[c.g.elems[v]] = l
+	}
+
+	return labels
+}
+
+// Canonical constructs the canonical form of b: the relation over the
+// integers 0, ..., n-1 relating the labels of each pair of b, according
+// to its CanonicalLabeling. Relations are isomorphic iff their
+// canonical forms contain the same pairs.
+func Canonical(b AbstractInterface) Interface {
+	c := canonicalize(b)
+	n := len(c.bestLab)
+
+	universe := set.New()
+	labels := make([]set.Element, n)
+	for l := range labels {
+		labels[l] = l
+		universe.Add(l)
+	}
+
+	h := newGraph(labels)
+	for v, succ := range c.g.succ {
+		for _, w := range succ {
+			h.rows[c.bestLab[v]].add(c.bestLab[w])
+		}
+	}
+
+	h.reindex()
+	return h.relation(universe)
+}
+
+// Certificate computes a string which identifies b up to isomorphism:
+// relations are isomorphic iff their certificates are equal. It is
+// suitable as a map key.
+func Certificate(b AbstractInterface) string {
+	return canonicalize(b).bestCert
+}
+
+// --- }}}
+
+// --- Isomorphism {{{
+
+// Isomorphic determines whether r and s are isomorphic.
+func Isomorphic(r, s AbstractInterface) bool {
+	_, ok := Isomorphism(r, s)
+	return ok
+}
+
+// Isomorphism returns an isomorphism φ from r to s, mapping each
+// element of the universe of r to one of the universe of s, such that
+// xRy ⇔ φ(x) S φ(y), if there is one.
+func Isomorphism(r, s AbstractInterface) (map[set.Element]set.Element, bool) {
+	cr, cs := canonicalize(r), canonicalize(s)
+
+	if cr.bestCert != cs.bestCert {
+		return nil, false
+	}
+
+	// the element of s with each label
+	inv := make([]set.Element, len(cs.bestLab))
+	for v, l := range cs.bestLab {
+		inv[l] = cs.g.elems[v]
+	}
+
+	phi := make(map[set.Element]set.Element, len(inv))
+	for v, l := range cr.bestLab {
+		phi[cr.g.elems[v]] = inv[l]
+	}
+
+	return phi, true
+}
+
+// UniqueUpToIsomorphism returns the first of each class of isomorphic
+// relations of rs, in order.
+func UniqueUpToIsomorphism(rs []AbstractInterface) []AbstractInterface {
+	seen := make(map[string]bool)
+
+	var unique []AbstractInterface
+	for _, r := range rs {
+		if cert := Certificate(r); !seen[cert] {
+			seen[cert] = true
+			unique = append(unique, r)
+		}
+	}
+
+	return unique
+}
+
+// --- }}}
+
+// --- Automorphisms {{{
+
+// AutomorphismGenerators returns automorphisms of b, isomorphisms from
+// b to itself, which generate its automorphism group: every
+// automorphism is a composition of them. The identity is not included,
+// so a relation with no other automorphism has no generators.
+func AutomorphismGenerators(b AbstractInterface) []map[set.Element]set.Element {
+	c := canonicalize(b)
+
+	gens := make([]map[set.Element]set.Element, len(c.gens))
+	for i, gen := range c.gens {
+		gens[i] = c.permutation(gen)
+	}
+
+	return gens
+}
+
+// permutation maps the elements of g by the vertex permutation p.
+func (c *canon) permutation(p []int) map[set.Element]set.Element {
+	m := make(map[set.Element]set.Element, len(p))
+	for v, w := range p {
+		m[c.g.elems[v]] = c.g.elems[w]
+	}
+	return m
+}
+
+// Automorphisms calls fn with each automorphism of b, beginning with
+// the identity. Enumeration stops early if fn returns false.
+//
+// The automorphism group is generated from AutomorphismGenerators, and
+// every automorphism enumerated is remembered, so enumerating a large
+// group, such as the n! automorphisms of the empty relation, is costly.
+func Automorphisms(b AbstractInterface, fn func(map[set.Element]set.Element) bool) {
+	c := canonicalize(b)
+	n := len(c.g.elems)
+
+	identity := make([]int, n)
+	for v := range identity {
+		identity[v] = v
+	}
+
+	seen := map[string]bool{encodeInts(identity): true}
+	queue := [][]int{identity}
+
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		if !fn(c.permutation(p)) {
+			return
+		}
+
+		for _, gen := range c.gens {
+			q := make([]int, n)
+			for v := range q {
+				q[v] = gen[p[v]]
+			}
+
+			if key := encodeInts(q); !seen[key] {
+				seen[key] = true
+				queue = append(queue, q)
+			}
+		}
+	}
+}
+
+// --- }}}
@@ -0,0 +1,278 @@
+package relation_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+// relabel constructs the relation over the images of the universe of b
+// under phi relating phi(x) to phi(y) iff xBy.
+func relabel(b relation.AbstractInterface, phi func(set.Element) set.Element) relation.Interface {
+	universe := set.New()
+	for _, x := range b.Universe().Elements() {
+		universe.Add(phi(x))
+	}
+
+	r := relation.New(universe)
+	for _, x := range b.Universe().Elements() {
+		for _, y := range b.Universe().Elements() {
+			if b.ContainsRelation(x, y) {
+				r.AddRelation(phi(x), phi(y))
+			}
+		}
+	}
+
+	return r
+}
+
+// isomorphism reports whether phi is an isomorphism from r to s.
+func isomorphism(r, s relation.AbstractInterface, phi map[set.Element]set.Element) bool {
+	if uint(len(phi)) != r.Universe().Cardinality() {
+		return false
+	}
+
+	for _, x := range r.Universe().Elements() {
+		for _, y := range r.Universe().Elements() {
+			if r.ContainsRelation(x, y) != s.ContainsRelation(phi[x], phi[y]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// random constructs a relation over {0, ..., n-1} containing each pair
+// with probability p.
+func random(rng *rand.Rand, n int, p float64) relation.Interface {
+	b := relation.New(chain(n).Universe())
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			if rng.Float64() < p {
+				b.AddRelation(x, y)
+			}
+		}
+	}
+	return b
+}
+
+func TestIsomorphic(t *testing.T) {
+	d := divides(1, 2, 3, 4, 6, 12)
+
+	// the divisors of 12 are isomorphic to those of 18, by 2 ↦ 3, 3 ↦ 2
+	e := divides(1, 2, 3, 6, 9, 18)
+
+	phi, ok := relation.Isomorphism(d, e)
+	if !ok {
+		t.Fatalf("Expected the divisors of 12 and 18 to be isomorphic")
+	}
+
+	if !isomorphism(d, e, phi) {
+		t.Errorf("Expected %v to be an isomorphism", phi)
+	}
+
+	if phi[4] != 9 || phi[12] != 18 {
+		t.Errorf("Expected 4 ↦ 9 and 12 ↦ 18, got %v", phi)
+	}
+
+	if relation.Isomorphic(d, divides(1, 2, 4, 8, 16, 32)) {
+		t.Errorf("Expected the divisors of 12 not to be isomorphic to those of 32")
+	}
+
+	if relation.Isomorphic(chain(3), chain(4)) {
+		t.Errorf("Expected chains of different lengths not to be isomorphic")
+	}
+
+	// a loop distinguishes otherwise isomorphic relations
+	looped := chain(3)
+	looped.AddRelation(0, 0)
+	other := chain(3)
+	other.AddRelation(2, 2)
+
+	if relation.Isomorphic(looped, other) {
+		t.Errorf("Expected a loop at the start not to be isomorphic to one at the end")
+	}
+}
+
+func TestIsomorphicLarge(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	b := random(rng, 200, 0.05)
+	perm := rng.Perm(200)
+	r := relabel(b, func(x set.Element) set.Element { return perm[x.(int)] })
+
+	phi, ok := relation.Isomorphism(b, r)
+	if !ok {
+		t.Fatalf("Expected a relabeled relation to be isomorphic")
+	}
+
+	if !isomorphism(b, r, phi) {
+		t.Errorf("Expected the isomorphism found to be an isomorphism")
+	}
+
+	// move one pair
+	for x := 0; x < 200; x++ {
+		if r.ContainsRelation(x, 0) && !r.ContainsRelation(x, 1) {
+			r.RemoveRelation(x, 0)
+			r.AddRelation(x, 1)
+			break
+		}
+	}
+
+	if relation.Isomorphic(b, r) {
+		t.Errorf("Expected moving a pair to break the isomorphism")
+	}
+}
+
+func TestIsomorphicRegular(t *testing.T) {
+	// relations in which refinement distinguishes no elements
+	empty := relation.New(chain(100).Universe())
+	full := relation.Complement(empty)
+
+	cycle := chain(100)
+	cycle.AddRelation(99, 0)
+
+	// two cycles of 50 have the same degrees as one of 100
+	cycles := chain(100)
+	cycles.RemoveRelation(49, 50)
+	cycles.AddRelation(49, 0)
+	cycles.AddRelation(99, 50)
+
+	rng := rand.New(rand.NewSource(2))
+	perm := rng.Perm(100)
+	shuffle := func(x set.Element) set.Element { return perm[x.(int)] }
+
+	for name, b := range map[string]relation.AbstractInterface{
+		"empty":  empty,
+		"full":   full,
+		"cycle":  cycle,
+		"cycles": cycles,
+	} {
+		r := relabel(b, shuffle)
+
+		phi, ok := relation.Isomorphism(b, r)
+		if !ok || !isomorphism(b, r, phi) {
+			t.Errorf("%s: Expected an isomorphism to the relabeled relation", name)
+		}
+	}
+
+	if relation.Isomorphic(cycle, cycles) {
+		t.Errorf("Expected one cycle of 100 not to be isomorphic to two of 50")
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	b := random(rng, 30, 0.2)
+
+	perm := rng.Perm(30)
+	r := relabel(b, func(x set.Element) set.Element { return perm[x.(int)] })
+
+	if relation.Certificate(b) != relation.Certificate(r) {
+		t.Errorf("Expected isomorphic relations to have the same certificate")
+	}
+
+	cb, cr := relation.Canonical(b), relation.Canonical(r)
+	if !set.Equivalent(cb.Universe(), chain(30).Universe()) {
+		t.Errorf("Expected the canonical form to be over {0, ..., 29}, got %s", cb.Universe())
+	}
+
+	if !equal(cb, cr) {
+		t.Errorf("Expected isomorphic relations to have the same canonical form")
+	}
+
+	labels := relation.CanonicalLabeling(b)
+	if !isomorphism(b, cb, func() map[set.Element]set.Element {
+		phi := make(map[set.Element]set.Element, len(labels))
+		for x, l := range labels {
+			phi[x] = l
+		}
+		return phi
+	}()) {
+		t.Errorf("Expected the canonical labeling to be an isomorphism to the canonical form")
+	}
+
+	if relation.Certificate(chain(3)) == relation.Certificate(relation.TransitiveClosure(chain(3))) {
+		t.Errorf("Expected a chain and its closure to have different certificates")
+	}
+}
+
+func TestAutomorphisms(t *testing.T) {
+	cycle := chain(6)
+	cycle.AddRelation(5, 0)
+
+	// the symmetric closure of the cycle is the hexagon, with the 12
+	// symmetries of the dihedral group
+	hexagon := relation.SymmetricClosure(cycle)
+
+	empty := relation.New(chain(5).Universe())
+
+	for name, c := range map[string]struct {
+		b     relation.AbstractInterface
+		order int
+	}{
+		"cycle":   {cycle, 6},
+		"hexagon": {hexagon, 12},
+		"empty":   {empty, 120},
+		"chain":   {chain(5), 1},
+	} {
+		for _, gen := range relation.AutomorphismGenerators(c.b) {
+			if !isomorphism(c.b, c.b, gen) {
+				t.Errorf("%s: Expected the generator %v to be an automorphism", name, gen)
+			}
+		}
+
+		seen := make(map[string]bool)
+		relation.Automorphisms(c.b, func(phi map[set.Element]set.Element) bool {
+			if !isomorphism(c.b, c.b, phi) {
+				t.Errorf("%s: Expected %v to be an automorphism", name, phi)
+			}
+
+			key := ""
+			for x := 0; x < len(phi); x++ {
+				key += string(rune('a' + phi[x].(int)))
+			}
+			seen[key] = true
+			return true
+		})
+
+		if len(seen) != c.order {
+			t.Errorf("%s: Expected %d automorphisms, got %d", name, c.order, len(seen))
+		}
+	}
+
+	count := 0
+	relation.Automorphisms(empty, func(map[set.Element]set.Element) bool {
+		count++
+		return count < 10
+	})
+
+	if count != 10 {
+		t.Errorf("Expected enumeration to stop after 10, got %d", count)
+	}
+}
+
+func TestUniqueUpToIsomorphism(t *testing.T) {
+	// there are 10 relations on 2 elements, and 104 on 3, up to
+	// isomorphism
+	for n, expected := range map[int]int{2: 10, 3: 104} {
+		var rs []relation.AbstractInterface
+
+		for mask := 0; mask < 1<<uint(n*n); mask++ {
+			b := relation.New(chain(n).Universe())
+			for bit := 0; bit < n*n; bit++ {
+				if mask&(1<<uint(bit)) != 0 {
+					b.AddRelation(bit/n, bit%n)
+				}
+			}
+			rs = append(rs, b)
+		}
+
+		if unique := relation.UniqueUpToIsomorphism(rs); len(unique) != expected {
+			t.Errorf("Expected %d relations on %d elements up to isomorphism, got %d", expected, n, len(unique))
+		}
+	}
+}
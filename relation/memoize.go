@@ -0,0 +1,177 @@
+package relation
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Memoization {{{
+
+// pair is the cache key of a pair of elements
+type pair struct {
+	x, y set.Element
+}
+
+// Memoize constructs a relation equivalent to b which remembers the
+// answer of b.ContainsRelation for every pair it is asked about, so
+// that an expensive RelatedPredicate is evaluated at most once per
+// pair. The cache grows to at most n^2 entries, where n =
+// |Universe()|; see MemoizeBounded.
+//
+// Each check and operation of this package, e.g., Transitive or
+// TransitiveClosure, takes its own snapshot of a predicate backed
+// relation, at a cost of n^2 calls to ContainsRelation, so k of them
+// cost kn^2 calls, but only n^2 if b is memoized. Lazy functions, such
+// as EachPair and BreadthFirst, test only the pairs they need, and a
+// memoized relation evaluates only those. To evaluate every pair once,
+// up front, use Materialize instead.
+//
+// The memoized relation is a snapshot: it does not reflect changes to
+// b after a pair has been cached. It is safe for concurrent use if b
+// is, though concurrent calls for a pair not yet cached may each
+// consult b.
+func Memoize(b AbstractInterface) AbstractInterface {
+	return &memoized{
+		b:     b,
+		cache: make(map[pair]bool),
+	}
+}
+
+// memoized caches every pair of b
+type memoized struct {
+	b AbstractInterface
+
+	mu    sync.RWMutex
+	cache map[pair]bool
+}
+
+// Universe returns the set over which the binary relation is defined.
+func (m *memoized) Universe() set.Interface {
+	return m.b.Universe()
+}
+
+// ContainsRelation determines whether x is related to y, consulting
+// the underlying relation only if the pair has not been cached.
+func (m *memoized) ContainsRelation(x, y set.Element) bool {
+	p := pair{x, y}
+
+	m.mu.RLock()
+	related, ok := m.cache[p]
+	m.mu.RUnlock()
+
+	if ok {
+		return related
+	}
+
+	// the lock is not held while b is consulted, so that an expensive
+	// predicate may be evaluated for distinct pairs concurrently
+	related = m.b.ContainsRelation(x, y)
+
+	m.mu.Lock()
+	m.cache[p] = related
+	m.mu.Unlock()
+
+	return related
+}
+
+// MemoizeBounded constructs a relation equivalent to b which remembers
+// the answers of b.ContainsRelation for at most capacity pairs,
+// forgetting the least recently used pair to make room for another.
+//
+// As with Memoize, cached pairs do not reflect later changes to b, and
+// the relation is safe for concurrent use if b is.
+//
+// MemoizeBounded panics if capacity is not positive.
+func MemoizeBounded(b AbstractInterface, capacity int) AbstractInterface {
+	if capacity <= 0 {
+		panic("relation: MemoizeBounded: capacity must be positive")
+	}
+
+	return &boundedMemoized{
+		b:        b,
+		capacity: capacity,
+		recent:   list.New(),
+		entries:  make(map[pair]*list.Element, capacity),
+	}
+}
+
+// boundedMemoized caches the most recently used pairs of b
+type boundedMemoized struct {
+	b        AbstractInterface
+	capacity int
+
+	mu sync.Mutex
+
+	// recent lists the cached entries, most recently used first
+	recent  *list.List
+	entries map[pair]*list.Element
+}
+
+// entry is a cached pair, and whether it is related
+type entry struct {
+	p       pair
+	related bool
+}
+
+// Universe returns the set over which the binary relation is defined.
+func (m *boundedMemoized) Universe() set.Interface {
+	return m.b.Universe()
+}
+
+// ContainsRelation determines whether x is related to y, consulting
+// the underlying relation only if the pair is not cached.
+func (m *boundedMemoized) ContainsRelation(x, y set.Element) bool {
+	p := pair{x, y}
+
+	m.mu.Lock()
+	if e, ok := m.entries[p]; ok {
+		m.recent.MoveToFront(e)
+		related := e.Value.(*entry).related
+		m.mu.Unlock()
+		return related
+	}
+	m.mu.Unlock()
+
+	related := m.b.ContainsRelation(x, y)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// another call may have cached the pair meanwhile
+	if e, ok := m.entries[p]; ok {
+		m.recent.MoveToFront(e)
+		return related
+	}
+
+	if m.recent.Len() == m.capacity {
+		oldest := m.recent.Back()
+		m.recent.Remove(oldest)
+		delete(m.entries, oldest.Value.(*entry).p)
+	}
+
+	m.entries[p] = m.recent.PushFront(&entry{p: p, related: related})
+	return related
+}
+
+// --- }}}
+
+// --- Parallel Materialize {{{
+
+// MaterializeParallel is Materialize, consulting b from workers
+// goroutines at once, each evaluating ContainsRelation for a share of
+// the rows of the universe. If workers is not positive, GOMAXPROCS
+// goroutines are used.
+//
+// Each pair is evaluated once, in one pass, so that it is worth
+// materializing a relation whose predicate is expensive before
+// checking its properties. The ContainsRelation method of b must be
+// safe for concurrent use.
+func MaterializeParallel(b AbstractInterface, workers int) Interface {
+	g, _ := graphParallel(context.Background(), b, workers)
+	return g.physical(b)
+}
+
+// --- }}}
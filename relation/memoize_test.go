@@ -0,0 +1,92 @@
+package relation_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+// counting constructs ≤ over numbers, counting its evaluations
+func counting(calls *int64) relation.AbstractInterface {
+	return relation.NewFunctionBinaryRelation(numbers, func(x, y set.Element) bool {
+		atomic.AddInt64(calls, 1)
+		return x.(int) <= y.(int)
+	})
+}
+
+func TestMemoize(t *testing.T) {
+	var calls int64
+	m := relation.Memoize(counting(&calls))
+
+	if !relation.Transitive(m) || !relation.AntiSymmetric(m) {
+		t.Fatalf("Expected ≤ to be transitive and antisymmetric")
+	}
+
+	n := int64(numbers.Cardinality())
+	if calls > n*n {
+		t.Errorf("Expected at most %d evaluations, got %d", n*n, calls)
+	}
+
+	before := calls
+	relation.Complete(m)
+
+	if calls != before {
+		t.Errorf("Expected no further evaluations, got %d", calls-before)
+	}
+}
+
+func TestMemoizeBounded(t *testing.T) {
+	var calls int64
+	m := relation.MemoizeBounded(counting(&calls), 2)
+
+	m.ContainsRelation(1, 2)
+	m.ContainsRelation(2, 3)
+	m.ContainsRelation(1, 2)
+
+	if calls != 2 {
+		t.Errorf("Expected (1, 2) to be cached, got %d evaluations", calls)
+	}
+
+	// (2, 3) is least recently used, so is forgotten
+	m.ContainsRelation(3, 4)
+	m.ContainsRelation(1, 2)
+	m.ContainsRelation(2, 3)
+
+	if calls != 4 {
+		t.Errorf("Expected only (2, 3) to have been forgotten, got %d evaluations", calls)
+	}
+
+	if !m.ContainsRelation(2, 3) || m.ContainsRelation(3, 2) {
+		t.Errorf("Expected the memoized relation to agree with ≤")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for a capacity of 0")
+		}
+	}()
+
+	relation.MemoizeBounded(lessEqual, 0)
+}
+
+func TestMaterializeParallel(t *testing.T) {
+	var calls int64
+	b := counting(&calls)
+
+	m := relation.MaterializeParallel(b, 4)
+
+	n := int64(numbers.Cardinality())
+	if calls != n*n {
+		t.Errorf("Expected each pair to be evaluated once, got %d evaluations", calls)
+	}
+
+	if !equal(m, lessEqual) || relation.Size(m) != uint(n*(n+1)/2) {
+		t.Errorf("Expected the materialized relation to be ≤")
+	}
+
+	if d := relation.MaterializeParallel(relation.NewDense(numbers), 0); relation.Size(d) != 0 {
+		t.Errorf("Expected an empty dense relation to materialize empty")
+	}
+}
@@ -0,0 +1,364 @@
+package nary
+
+import (
+	"fmt"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Helpers {{{
+
+// build constructs a relation with heading from the tuples ts, each of
+// which must assign exactly the attributes of heading.
+func build(heading []Attribute, ts []Tuple) *relation {
+	r, err := newRelation(heading)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, t := range ts {
+		vs, err := r.values(t)
+		if err != nil {
+			panic(err)
+		}
+
+		r.insert(vs)
+	}
+
+	return r
+}
+
+// attribute finds the attribute of r called name.
+func attribute(r Interface, name string) (Attribute, bool) {
+	for _, a := range r.Heading() {
+		if a.Name == name {
+			return a, true
+		}
+	}
+
+	return Attribute{}, false
+}
+
+// sameNames reports whether r and s have the same attribute names.
+func sameNames(r, s Interface) bool {
+	hr, hs := r.Heading(), s.Heading()
+	if len(hr) != len(hs) {
+		return false
+	}
+
+	for _, a := range hr {
+		if _, ok := attribute(s, a.Name); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// project restricts t to the attributes named by names.
+func project(t Tuple, names []string) Tuple {
+	p := make(Tuple, len(names))
+	for _, n := range names {
+		p[n] = t[n]
+	}
+	return p
+}
+
+// valuesOf lists the values of t for the attributes named by names.
+func valuesOf(t Tuple, names []string) []set.Element {
+	vs := make([]set.Element, len(names))
+	for i, n := range names {
+		vs[i] = t[n]
+	}
+	return vs
+}
+
+// merge constructs the tuple assigning the attributes of both t and u.
+func merge(t, u Tuple) Tuple {
+	m := make(Tuple, len(t)+len(u))
+	for n, v := range t {
+		m[n] = v
+	}
+	for n, v := range u {
+		m[n] = v
+	}
+	return m
+}
+
+// --- }}}
+
+// --- Unary Operations {{{
+
+// Select constructs σ_p(r), the relation of the tuples of r satisfying
+// the predicate p, with the heading of r.
+func Select(r Interface, p func(Tuple) bool) Interface {
+	var ts []Tuple
+
+	for _, t := range r.Tuples() {
+		if p(t) {
+			ts = append(ts, t)
+		}
+	}
+
+	return build(r.Heading(), ts)
+}
+
+// Project constructs π_names(r), the relation of the tuples of r
+// restricted to the named attributes, in the order given.
+//
+// An error wrapping ErrHeading is returned if r has no attribute of
+// some name, or a name is given twice.
+func Project(r Interface, names ...string) (Interface, error) {
+	heading := make([]Attribute, len(names))
+
+	for i, n := range names {
+		a, ok := attribute(r, n)
+		if !ok {
+			return nil, fmt.Errorf("%w: no attribute %q", ErrHeading, n)
+		}
+
+		heading[i] = a
+	}
+
+	p, err := newRelation(heading)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range r.Tuples() {
+		p.insert(valuesOf(t, names))
+	}
+
+	return p, nil
+}
+
+// Rename constructs ρ_to/from(r), the relation r with the attribute
+// from renamed to.
+//
+// An error wrapping ErrHeading is returned if r has no attribute from,
+// or already has an attribute to.
+func Rename(r Interface, from, to string) (Interface, error) {
+	if _, ok := attribute(r, from); !ok {
+		return nil, fmt.Errorf("%w: no attribute %q", ErrHeading, from)
+	}
+
+	if _, ok := attribute(r, to); ok && from != to {
+		return nil, fmt.Errorf("%w: attribute %q already exists", ErrHeading, to)
+	}
+
+	heading := r.Heading()
+	for i := range heading {
+		if heading[i].Name == from {
+			heading[i].Name = to
+		}
+	}
+
+	ts := r.Tuples()
+	for _, t := range ts {
+		v := t[from]
+		delete(t, from)
+		t[to] = v
+	}
+
+	return build(heading, ts), nil
+}
+
+// --- }}}
+
+// --- Joins {{{
+
+// NaturalJoin constructs r ⋈ s, the relation of the tuples formed by
+// combining each tuple of r with each tuple of s which agrees with it
+// on their common attributes. The heading is that of r followed by the
+// attributes of s not in r. If r and s share no attributes, NaturalJoin
+// is the cartesian product.
+func NaturalJoin(r, s Interface) Interface {
+	heading := r.Heading()
+
+	var common []string
+	for _, a := range s.Heading() {
+		if _, ok := attribute(r, a.Name); ok {
+			common = append(common, a.Name)
+		} else {
+			heading = append(heading, a)
+		}
+	}
+
+	// hash the tuples of s by their values of the common attributes
+	in := make(interner)
+	bucket := make(map[string][]Tuple)
+	for _, u := range s.Tuples() {
+		k := in.key(valuesOf(u, common))
+		bucket[k] = append(bucket[k], u)
+	}
+
+	var ts []Tuple
+	for _, t := range r.Tuples() {
+		k, ok := in.lookup(valuesOf(t, common))
+		if !ok {
+			continue
+		}
+
+		for _, u := range bucket[k] {
+			ts = append(ts, merge(t, u))
+		}
+	}
+
+	return build(heading, ts)
+}
+
+// ThetaJoin constructs r ⋈_θ s, the relation of the tuples formed by
+// combining a tuple of r with a tuple of s which together satisfy the
+// predicate theta. The heading is that of r followed by that of s.
+//
+// An error wrapping ErrHeading is returned if r and s share an
+// attribute; see Rename.
+func ThetaJoin(r, s Interface, theta func(Tuple) bool) (Interface, error) {
+	heading := r.Heading()
+
+	for _, a := range s.Heading() {
+		if _, ok := attribute(r, a.Name); ok {
+			return nil, fmt.Errorf("%w: attribute %q is shared", ErrHeading, a.Name)
+		}
+
+		heading = append(heading, a)
+	}
+
+	var ts []Tuple
+	us := s.Tuples()
+
+	for _, t := range r.Tuples() {
+		for _, u := range us {
+			if m := merge(t, u); theta(m) {
+				ts = append(ts, m)
+			}
+		}
+	}
+
+	return build(heading, ts), nil
+}
+
+// --- }}}
+
+// --- Set Operations {{{
+
+// Union constructs r ∪ s, the relation of the tuples of either r or s.
+// The domain of each attribute is the union of its domains in r and s.
+//
+// An error wrapping ErrHeading is returned if r and s do not have the
+// same attribute names.
+func Union(r, s Interface) (Interface, error) {
+	if !sameNames(r, s) {
+		return nil, fmt.Errorf("%w: union requires the same attributes", ErrHeading)
+	}
+
+	heading := r.Heading()
+	for i, a := range heading {
+		b, _ := attribute(s, a.Name)
+		heading[i].Domain = set.Union(a.Domain, b.Domain)
+	}
+
+	return build(heading, append(r.Tuples(), s.Tuples()...)), nil
+}
+
+// Intersection constructs r ∩ s, the relation of the tuples of both r
+// and s, with the heading of r.
+//
+// An error wrapping ErrHeading is returned if r and s do not have the
+// same attribute names.
+func Intersection(r, s Interface) (Interface, error) {
+	if !sameNames(r, s) {
+		return nil, fmt.Errorf("%w: intersection requires the same attributes", ErrHeading)
+	}
+
+	return Select(r, s.Contains), nil
+}
+
+// Difference constructs r \ s, the relation of the tuples of r which
+// are not tuples of s, with the heading of r.
+//
+// An error wrapping ErrHeading is returned if r and s do not have the
+// same attribute names.
+func Difference(r, s Interface) (Interface, error) {
+	if !sameNames(r, s) {
+		return nil, fmt.Errorf("%w: difference requires the same attributes", ErrHeading)
+	}
+
+	return Select(r, func(t Tuple) bool {
+		return !s.Contains(t)
+	}), nil
+}
+
+// Divide constructs r ÷ s, for s whose attributes are among those of
+// r: the largest relation q, over the attributes of r not in s, such
+// that q ⋈ s ⊆ r. That is, the tuples which are combined in r with
+// every tuple of s. For example, with r relating students to the
+// courses they have passed, and s a set of courses, r ÷ s is the
+// students who have passed every course in s.
+//
+// An error wrapping ErrHeading is returned if some attribute of s is
+// not an attribute of r.
+func Divide(r, s Interface) (Interface, error) {
+	var divisor []string
+	for _, a := range s.Heading() {
+		if _, ok := attribute(r, a.Name); !ok {
+			return nil, fmt.Errorf("%w: divisor attribute %q is not in the dividend", ErrHeading, a.Name)
+		}
+
+		divisor = append(divisor, a.Name)
+	}
+
+	var heading []Attribute
+	var quotient []string
+	for _, a := range r.Heading() {
+		if _, ok := attribute(s, a.Name); !ok {
+			heading = append(heading, a)
+			quotient = append(quotient, a.Name)
+		}
+	}
+
+	// the divisor values with which each quotient value is combined in r
+	in := make(interner)
+	candidates := make(map[string]Tuple)
+	combined := make(map[string]map[string]bool)
+
+	for _, t := range r.Tuples() {
+		k := in.key(valuesOf(t, quotient))
+
+		if _, ok := candidates[k]; !ok {
+			candidates[k] = project(t, quotient)
+			combined[k] = make(map[string]bool)
+		}
+
+		combined[k][in.key(valuesOf(t, divisor))] = true
+	}
+
+	// the keys of the divisor values of s; if some value is combined
+	// with no tuple of r, no candidate qualifies
+	var ds []string
+	for _, u := range s.Tuples() {
+		d, ok := in.lookup(valuesOf(u, divisor))
+		if !ok {
+			return build(heading, nil), nil
+		}
+
+		ds = append(ds, d)
+	}
+
+	var ts []Tuple
+
+Candidates:
+	for k, t := range candidates {
+		for _, d := range ds {
+			if !combined[k][d] {
+				continue Candidates
+			}
+		}
+
+		ts = append(ts, t)
+	}
+
+	return build(heading, ts), nil
+}
+
+// --- }}}
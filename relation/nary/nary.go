@@ -0,0 +1,301 @@
+/*
+Package nary defines n-ary relations with named attributes, and the
+operations of the relational algebra over them: selection, projection,
+renaming, natural and theta joins, union, intersection, difference and
+division.
+
+An n-ary relation is a set of tuples. Each tuple assigns a value to
+every attribute in the relation's heading, drawn from the set which is
+that attribute's domain:
+
+	employees := nary.New(
+		nary.Attribute{Name: "name", Domain: names},
+		nary.Attribute{Name: "dept", Domain: depts},
+	)
+
+	employees.Insert(nary.Tuple{"name": "ada", "dept": "engines"})
+
+As with package set, values are compared with ==.
+*/
+package nary
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Types {{{
+
+type (
+	// An Attribute names a component of the tuples of a relation, and
+	// gives the set from which its values are drawn.
+	Attribute struct {
+		Name   string
+		Domain set.Interface
+	}
+
+	// A Tuple assigns a value to each attribute of a relation, by name.
+	Tuple map[string]set.Element
+
+	// Interface is the interface of an n-ary relation: a finite set of
+	// Tuples over a fixed heading.
+	Interface interface {
+		// Heading retrieves the attributes of the relation.
+		//
+		// Note: Mutating this slice must not modify the relation.
+		Heading() []Attribute
+
+		// Insert includes t as a member of the relation. An error is
+		// returned if t does not assign exactly the attributes of the
+		// heading, or assigns a value outside an attribute's domain.
+		//
+		// Note: Insert must be idempotent.
+		Insert(t Tuple) error
+
+		// Delete excludes t as a member of the relation.
+		//
+		// Note: Delete must be idempotent.
+		Delete(t Tuple)
+
+		// Contains returns a boolean indicating membership of t.
+		Contains(t Tuple) bool
+
+		// Cardinality retrieves the number of tuples.
+		Cardinality() uint
+
+		// Tuples retrieves a slice of all member tuples.
+		//
+		// Note: Mutating these tuples must not modify the relation.
+		Tuples() []Tuple
+	}
+)
+
+var (
+	// ErrTuple is reported when a tuple does not assign exactly the
+	// attributes of the heading of a relation, or assigns a value
+	// which is not a member of its attribute's domain.
+	ErrTuple = errors.New("nary: tuple does not match heading")
+
+	// ErrHeading is reported when the headings of relations are not
+	// suitable for an operation, e.g., the union of relations with
+	// different attributes.
+	ErrHeading = errors.New("nary: incompatible headings")
+)
+
+// --- }}}
+
+// --- Constructors {{{
+
+// New constructs an empty relation with the given heading.
+//
+// New panics with an error wrapping ErrHeading if two attributes share
+// a name.
+func New(heading ...Attribute) Interface {
+	r, err := newRelation(heading)
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// newRelation constructs an empty relation, or reports a duplicate
+// attribute.
+func newRelation(heading []Attribute) (*relation, error) {
+	r := &relation{
+		heading: append([]Attribute(nil), heading...),
+		index:   make(map[string]int, len(heading)),
+		ids:     make(interner),
+		tuples:  make(map[string][]set.Element),
+	}
+
+	for i, a := range heading {
+		if _, dup := r.index[a.Name]; dup {
+			return nil, fmt.Errorf("%w: attribute %q appears twice", ErrHeading, a.Name)
+		}
+
+		r.index[a.Name] = i
+	}
+
+	return r, nil
+}
+
+// --- }}}
+
+// --- Relation Implementation {{{
+
+// relation is a map backed n-ary relation. Each tuple is stored as
+// its values in the order of the heading, keyed by an encoding of
+// those values.
+type relation struct {
+	heading []Attribute
+	index   map[string]int
+
+	// ids numbers each value which has appeared in a tuple, so that
+	// tuples may be encoded as keys
+	ids    interner
+	tuples map[string][]set.Element
+}
+
+// Heading retrieves the attributes of the relation.
+func (r *relation) Heading() []Attribute {
+	return append([]Attribute(nil), r.heading...)
+}
+
+// values orders the values of t by the heading, verifying that t
+// assigns exactly the attributes of the heading.
+func (r *relation) values(t Tuple) ([]set.Element, error) {
+	if len(t) != len(r.heading) {
+		return nil, fmt.Errorf("%w: %d attributes given, but heading has %d", ErrTuple, len(t), len(r.heading))
+	}
+
+	vs := make([]set.Element, len(r.heading))
+
+	for i, a := range r.heading {
+		v, ok := t[a.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: attribute %q not given", ErrTuple, a.Name)
+		}
+
+		vs[i] = v
+	}
+
+	return vs, nil
+}
+
+// interner numbers values, so that tuples of values may be encoded
+// as map keys
+type interner map[set.Element]uint64
+
+// key encodes the values vs, numbering any which are new.
+func (in interner) key(vs []set.Element) string {
+	k, _ := in.encode(vs, true)
+	return k
+}
+
+// lookup encodes the values vs, and reports whether every value has
+// been numbered; if not, no tuple of vs has been encoded.
+func (in interner) lookup(vs []set.Element) (string, bool) {
+	return in.encode(vs, false)
+}
+
+func (in interner) encode(vs []set.Element, number bool) (string, bool) {
+	buf := make([]byte, binary.MaxVarintLen64*len(vs))
+	n := 0
+
+	for _, v := range vs {
+		id, ok := in[v]
+		if !ok {
+			if !number {
+				return "", false
+			}
+
+			id = uint64(len(in))
+			in[v] = id
+		}
+
+		n += binary.PutUvarint(buf[n:], id)
+	}
+
+	return string(buf[:n]), true
+}
+
+// insert includes the values vs, which must match the heading.
+func (r *relation) insert(vs []set.Element) {
+	r.tuples[r.ids.key(vs)] = vs
+}
+
+// Insert includes t as a member of the relation.
+func (r *relation) Insert(t Tuple) error {
+	vs, err := r.values(t)
+	if err != nil {
+		return err
+	}
+
+	for i, a := range r.heading {
+		if !a.Domain.Contains(vs[i]) {
+			return fmt.Errorf("%w: %v is not contained in the domain of %q", ErrTuple, vs[i], a.Name)
+		}
+	}
+
+	r.insert(vs)
+	return nil
+}
+
+// Delete excludes t as a member of the relation.
+func (r *relation) Delete(t Tuple) {
+	vs, err := r.values(t)
+	if err != nil {
+		return
+	}
+
+	if k, ok := r.ids.lookup(vs); ok {
+		delete(r.tuples, k)
+	}
+}
+
+// Contains returns a boolean indicating membership of t.
+func (r *relation) Contains(t Tuple) bool {
+	vs, err := r.values(t)
+	if err != nil {
+		return false
+	}
+
+	k, ok := r.ids.lookup(vs)
+	if !ok {
+		return false
+	}
+
+	_, contains := r.tuples[k]
+	return contains
+}
+
+// Cardinality retrieves the number of tuples.
+func (r *relation) Cardinality() uint {
+	return uint(len(r.tuples))
+}
+
+// Tuples retrieves a slice of all member tuples.
+func (r *relation) Tuples() []Tuple {
+	ts := make([]Tuple, 0, len(r.tuples))
+
+	for _, vs := range r.tuples {
+		ts = append(ts, r.tuple(vs))
+	}
+
+	return ts
+}
+
+// tuple constructs the Tuple of the values vs.
+func (r *relation) tuple(vs []set.Element) Tuple {
+	t := make(Tuple, len(vs))
+	for i, a := range r.heading {
+		t[a.Name] = vs[i]
+	}
+	return t
+}
+
+// String generates a string representation of the relation of the
+// form "{(a: 1, b: 2), ...}", with attributes in heading order.
+func (r *relation) String() string {
+	rows := make([]string, 0, len(r.tuples))
+
+	for _, vs := range r.tuples {
+		fields := make([]string, len(vs))
+		for i, a := range r.heading {
+			fields[i] = fmt.Sprintf("%s: %v", a.Name, vs[i])
+		}
+
+		rows = append(rows, fmt.Sprintf("(%s)", strings.Join(fields, ", ")))
+	}
+
+	sort.Strings(rows)
+	return fmt.Sprintf("{%s}", strings.Join(rows, ", "))
+}
+
+// --- }}}
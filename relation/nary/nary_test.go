@@ -0,0 +1,238 @@
+package nary_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation/nary"
+)
+
+var (
+	students = set.WithElements("ada", "bob", "cy")
+	courses  = set.WithElements("logic", "sets", "graphs")
+	rooms    = set.WithElements("a1", "b2")
+)
+
+// passed relates students to the courses they have passed
+func passed() nary.Interface {
+	r := nary.New(
+		nary.Attribute{Name: "student", Domain: students},
+		nary.Attribute{Name: "course", Domain: courses},
+	)
+
+	for _, p := range [][2]string{
+		{"ada", "logic"}, {"ada", "sets"}, {"ada", "graphs"},
+		{"bob", "logic"}, {"bob", "sets"},
+		{"cy", "graphs"},
+	} {
+		if err := r.Insert(nary.Tuple{"student": p[0], "course": p[1]}); err != nil {
+			panic(err)
+		}
+	}
+
+	return r
+}
+
+func TestInsert(t *testing.T) {
+	r := passed()
+
+	if r.Cardinality() != 6 {
+		t.Fatalf("Expected 6 tuples, got %d", r.Cardinality())
+	}
+
+	if err := r.Insert(nary.Tuple{"student": "ada", "course": "logic"}); err != nil || r.Cardinality() != 6 {
+		t.Errorf("Expected Insert to be idempotent")
+	}
+
+	if err := r.Insert(nary.Tuple{"student": "dan", "course": "logic"}); !errors.Is(err, nary.ErrTuple) {
+		t.Errorf("Expected a value outside its domain to be refused, got %v", err)
+	}
+
+	if err := r.Insert(nary.Tuple{"student": "ada"}); !errors.Is(err, nary.ErrTuple) {
+		t.Errorf("Expected a tuple missing an attribute to be refused, got %v", err)
+	}
+
+	if !r.Contains(nary.Tuple{"student": "cy", "course": "graphs"}) {
+		t.Errorf("Expected (cy, graphs) to be contained")
+	}
+
+	if r.Contains(nary.Tuple{"student": "cy", "course": "logic"}) {
+		t.Errorf("Expected (cy, logic) not to be contained")
+	}
+
+	r.Delete(nary.Tuple{"student": "cy", "course": "graphs"})
+	if r.Contains(nary.Tuple{"student": "cy", "course": "graphs"}) || r.Cardinality() != 5 {
+		t.Errorf("Expected (cy, graphs) to be deleted")
+	}
+
+	ts := r.Tuples()
+	ts[0]["student"] = "zed"
+	if r.Cardinality() != 5 || r.Contains(ts[0]) {
+		t.Errorf("Expected mutating a tuple not to modify the relation")
+	}
+}
+
+func TestNewDuplicateAttribute(t *testing.T) {
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, nary.ErrHeading) {
+			t.Errorf("Expected a panic wrapping ErrHeading, got %v", err)
+		}
+	}()
+
+	nary.New(
+		nary.Attribute{Name: "x", Domain: students},
+		nary.Attribute{Name: "x", Domain: courses},
+	)
+}
+
+func TestSelectProject(t *testing.T) {
+	r := passed()
+
+	ada := nary.Select(r, func(t nary.Tuple) bool {
+		return t["student"] == "ada"
+	})
+
+	if ada.Cardinality() != 3 {
+		t.Errorf("Expected ada to have passed 3 courses, got %d", ada.Cardinality())
+	}
+
+	p, err := nary.Project(r, "course")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Cardinality() != 3 || len(p.Heading()) != 1 {
+		t.Errorf("Expected the projection onto course to have 3 tuples, got %v", p)
+	}
+
+	if _, err := nary.Project(r, "grade"); !errors.Is(err, nary.ErrHeading) {
+		t.Errorf("Expected projection onto an unknown attribute to fail, got %v", err)
+	}
+
+	if _, err := nary.Project(r, "course", "course"); !errors.Is(err, nary.ErrHeading) {
+		t.Errorf("Expected projection onto a repeated attribute to fail, got %v", err)
+	}
+}
+
+func TestRename(t *testing.T) {
+	r, err := nary.Rename(passed(), "student", "who")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Heading()[0].Name != "who" || !r.Contains(nary.Tuple{"who": "bob", "course": "sets"}) {
+		t.Errorf("Expected student to be renamed who, got %v", r)
+	}
+
+	if _, err := nary.Rename(r, "who", "course"); !errors.Is(err, nary.ErrHeading) {
+		t.Errorf("Expected renaming onto an existing attribute to fail, got %v", err)
+	}
+}
+
+func TestJoins(t *testing.T) {
+	taught := nary.New(
+		nary.Attribute{Name: "course", Domain: courses},
+		nary.Attribute{Name: "room", Domain: rooms},
+	)
+	taught.Insert(nary.Tuple{"course": "logic", "room": "a1"})
+	taught.Insert(nary.Tuple{"course": "sets", "room": "a1"})
+	taught.Insert(nary.Tuple{"course": "graphs", "room": "b2"})
+
+	j := nary.NaturalJoin(passed(), taught)
+
+	if j.Cardinality() != 6 || len(j.Heading()) != 3 {
+		t.Fatalf("Expected 6 tuples over 3 attributes, got %v", j)
+	}
+
+	if !j.Contains(nary.Tuple{"student": "cy", "course": "graphs", "room": "b2"}) {
+		t.Errorf("Expected (cy, graphs, b2) in the join")
+	}
+
+	product := nary.NaturalJoin(
+		nary.New(nary.Attribute{Name: "student", Domain: students}),
+		nary.New(nary.Attribute{Name: "room", Domain: rooms}),
+	)
+	if product.Cardinality() != 0 {
+		t.Errorf("Expected the product with an empty relation to be empty")
+	}
+
+	r, _ := nary.Rename(taught, "course", "other")
+	theta, err := nary.ThetaJoin(passed(), r, func(t nary.Tuple) bool {
+		return t["course"] == t["other"] && t["room"] == "a1"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if theta.Cardinality() != 4 || len(theta.Heading()) != 4 {
+		t.Errorf("Expected 4 tuples over 4 attributes, got %v", theta)
+	}
+
+	if _, err := nary.ThetaJoin(passed(), taught, nil); !errors.Is(err, nary.ErrHeading) {
+		t.Errorf("Expected a theta join with a shared attribute to fail, got %v", err)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	r := passed()
+	s := nary.New(
+		nary.Attribute{Name: "course", Domain: set.WithElements("logic", "music")},
+		nary.Attribute{Name: "student", Domain: students},
+	)
+	s.Insert(nary.Tuple{"student": "cy", "course": "logic"})
+	s.Insert(nary.Tuple{"student": "ada", "course": "logic"})
+	s.Insert(nary.Tuple{"student": "ada", "course": "music"})
+
+	u, err := nary.Union(r, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Cardinality() != 8 {
+		t.Errorf("Expected 8 tuples in the union, got %v", u)
+	}
+
+	if err := u.Insert(nary.Tuple{"student": "bob", "course": "music"}); err != nil {
+		t.Errorf("Expected the domains to be combined, got %v", err)
+	}
+
+	i, _ := nary.Intersection(r, s)
+	if i.Cardinality() != 1 || !i.Contains(nary.Tuple{"student": "ada", "course": "logic"}) {
+		t.Errorf("Expected the intersection to be {(ada, logic)}, got %v", i)
+	}
+
+	d, _ := nary.Difference(r, s)
+	if d.Cardinality() != 5 || d.Contains(nary.Tuple{"student": "ada", "course": "logic"}) {
+		t.Errorf("Expected (ada, logic) to be removed, got %v", d)
+	}
+
+	if _, err := nary.Union(r, nary.New()); !errors.Is(err, nary.ErrHeading) {
+		t.Errorf("Expected the union of different headings to fail, got %v", err)
+	}
+}
+
+func TestDivide(t *testing.T) {
+	required := nary.New(nary.Attribute{Name: "course", Domain: courses})
+	required.Insert(nary.Tuple{"course": "logic"})
+	required.Insert(nary.Tuple{"course": "sets"})
+
+	q, err := nary.Divide(passed(), required)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if q.Cardinality() != 2 || !q.Contains(nary.Tuple{"student": "ada"}) || !q.Contains(nary.Tuple{"student": "bob"}) {
+		t.Errorf("Expected ada and bob to have passed logic and sets, got %v", q)
+	}
+
+	// every student has passed each of no courses
+	none := nary.New(nary.Attribute{Name: "course", Domain: courses})
+	if q, err := nary.Divide(passed(), none); err != nil || q.Cardinality() != 3 {
+		t.Errorf("Expected every student to divide by the empty relation, got %v, %v", q, err)
+	}
+
+	if _, err := nary.Divide(required, passed()); !errors.Is(err, nary.ErrHeading) {
+		t.Errorf("Expected division by a wider relation to fail, got %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package relation
+
+import (
+	"fmt"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Operations {{{
+
+// The operations in this file are lazy: they construct predicate
+// backed relations which consult their operands on every call to
+// ContainsRelation, and so reflect later changes to the operands.
+// Use Materialize to obtain a physical relation instead.
+//
+// The views do not check that elements are contained in the universe,
+// but pass them on to their operands, which panic with a *UniverseError
+// if they are physical.
+//
+// The binary operations require their operands to be defined over
+// equivalent universes, and panic with ErrUniverseMismatch otherwise.
+
+// Converse constructs the converse relation, B⁻¹:
+//
+//	y B⁻¹ x ⇔ xBy
+//
+// For example, the converse of ≥ is ≤.
+func Converse(b AbstractInterface) AbstractInterface {
+	return NewFunctionBinaryRelation(b.Universe(), func(x, y set.Element) bool {
+		return b.ContainsRelation(y, x)
+	})
+}
+
+// Complement constructs the complement of the relation, (X × X) \ B:
+//
+//	x Bᶜ y ⇔ not xBy
+//
+// For example, the complement of ≥ is <.
+func Complement(b AbstractInterface) AbstractInterface {
+	return NewFunctionBinaryRelation(b.Universe(), func(x, y set.Element) bool {
+		return !b.ContainsRelation(x, y)
+	})
+}
+
+// Compose constructs the composition R;S of the relations r and s:
+//
+//	x (R;S) z ⇔ there exists y ∈ X such that xRy and ySz
+//
+// Note the order: R is applied first. In the notation of functions
+// R;S is S ∘ R. Each call to ContainsRelation of the composition costs
+// up to 2n calls to the operands, where n = |Universe()|, but the
+// closures and property checks of this package, as well as Materialize,
+// compose a snapshot of the operands a machine word at a time.
+func Compose(r, s AbstractInterface) AbstractInterface {
+	mustCompose("Compose", r, s)
+
+	return &composition{r: r, s: s}
+}
+
+// composition is the lazy composition R;S of two relations
+type composition struct {
+	r, s AbstractInterface
+}
+
+// Universe returns the set over which the composition is defined.
+func (c *composition) Universe() set.Interface {
+	return c.r.Universe()
+}
+
+// ContainsRelation determines whether x (R;S) z.
+func (c *composition) ContainsRelation(x, z set.Element) bool {
+	for _, y := range c.r.Universe().Elements() {
+		if c.r.ContainsRelation(x, y) && c.s.ContainsRelation(y, z) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// graph snapshots the composition: the successors of x under R;S are
+// the union of the successors under S of the successors of x under R.
+// It returns nil if the snapshots of the operands cannot be aligned.
+func (c *composition) graph() *graph {
+	return composeGraphs(graphOf(c.r), graphOf(c.s))
+}
+
+// composeGraphs constructs the composition of the snapshots gr and gs,
+// over the elements of gr, or returns nil if they cannot be aligned.
+func composeGraphs(gr, gs *graph) *graph {
+	gs = gs.align(gr)
+	if gs == nil {
+		return nil
+	}
+
+	g := newGraph(gr.elems)
+	for x, succ := range gr.succ {
+		for _, y := range succ {
+			g.rows[x].union(gs.rows[y])
+		}
+	}
+
+	g.reindex()
+	return g
+}
+
+// Union constructs the union of the relations r and s, R ∪ S.
+func Union(r, s AbstractInterface) AbstractInterface {
+	mustCompose("Union", r, s)
+
+	return NewFunctionBinaryRelation(r.Universe(), func(x, y set.Element) bool {
+		return r.ContainsRelation(x, y) || s.ContainsRelation(x, y)
+	})
+}
+
+// Intersection constructs the intersection of the relations r and s, R ∩ S.
+func Intersection(r, s AbstractInterface) AbstractInterface {
+	mustCompose("Intersection", r, s)
+
+	return NewFunctionBinaryRelation(r.Universe(), func(x, y set.Element) bool {
+		return r.ContainsRelation(x, y) && s.ContainsRelation(x, y)
+	})
+}
+
+// Difference constructs the difference of the relations r and s, R \ S.
+func Difference(r, s AbstractInterface) AbstractInterface {
+	mustCompose("Difference", r, s)
+
+	return NewFunctionBinaryRelation(r.Universe(), func(x, y set.Element) bool {
+		return r.ContainsRelation(x, y) && !s.ContainsRelation(x, y)
+	})
+}
+
+// mustCompose panics with ErrUniverseMismatch if r and s are not
+// defined over equivalent universes.
+func mustCompose(op string, r, s AbstractInterface) {
+	if !ComposableRelations([]AbstractInterface{r, s}) {
+		panic(fmt.Errorf("relation: %s: %w", op, ErrUniverseMismatch))
+	}
+}
+
+// --- }}}
+
+// --- Materialize {{{
+
+// Materialize constructs a physical relation over the universe of b,
+// containing exactly the pairs contained in b at the time of the call.
+// The result is dense if b is, and map backed otherwise. See
+// MaterializeParallel to consult an expensive relation concurrently.
+func Materialize(b AbstractInterface) Interface {
+	return graphOf(b).physical(b)
+}
+
+// physical constructs a physical relation over the universe of b from
+// the edges of g, which must be a snapshot of b. The representation is
+// dense if b is, or if b is composed of dense relations.
+func (g *graph) physical(b AbstractInterface) Interface {
+	if isDense(b) {
+		return newDense(b.Universe(), g)
+	}
+
+	return g.relation(b.Universe())
+}
+
+// isDense reports whether b is dense, or composed of dense relations.
+func isDense(b AbstractInterface) bool {
+	switch r := b.(type) {
+	case *denseRelation:
+		return true
+	case *composition:
+		return isDense(r.r) && isDense(r.s)
+	}
+
+	return false
+}
+
+// relation constructs a physical relation over universe, which must
+// contain the elements of g, from the edges of g.
+func (g *graph) relation(universe set.Interface) Interface {
+	b := &binaryRelation{
+		universe:  universe,
+		relations: make(map[set.Element]map[set.Element]bool),
+	}
+
+	for x, succ := range g.succ {
+		if len(succ) == 0 {
+			continue
+		}
+
+		bucket := make(map[set.Element]bool, len(succ))
+		for _, y := range succ {
+			bucket[g.elems[y]] = true
+		}
+
+		b.relations[g.elems[x]] = bucket
+	}
+
+	return b
+}
+
+// --- }}}
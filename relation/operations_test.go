@@ -0,0 +1,133 @@
+package relation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+func TestConverseAndComplement(t *testing.T) {
+	greaterEqual := relation.Converse(lessEqual)
+
+	if !greaterEqual.ContainsRelation(5, 3) || greaterEqual.ContainsRelation(3, 5) {
+		t.Errorf("Expected the converse of ≤ to be ≥")
+	}
+
+	if !relation.Reverse(lessEqual).ContainsRelation(5, 3) {
+		t.Errorf("Expected Reverse to construct the converse")
+	}
+
+	greater := relation.Complement(lessEqual)
+
+	if !greater.ContainsRelation(5, 3) || greater.ContainsRelation(3, 3) {
+		t.Errorf("Expected the complement of ≤ to be >")
+	}
+}
+
+func TestCompose(t *testing.T) {
+	s := set.WithElements(0, 1, 2, 3)
+	succ := relation.New(s)
+
+	succ.AddRelation(0, 1)
+	succ.AddRelation(1, 2)
+	succ.AddRelation(2, 3)
+
+	plusTwo := relation.Compose(succ, succ)
+
+	for _, x := range s.Elements() {
+		for _, y := range s.Elements() {
+			expected := y.(int) == x.(int)+2
+			if plusTwo.ContainsRelation(x, y) != expected {
+				t.Errorf("Expected (%v, %v) ∈ succ;succ to be %t", x, y, expected)
+			}
+		}
+	}
+
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, relation.ErrUniverseMismatch) {
+			t.Errorf("Expected composing relations over different universes to panic with ErrUniverseMismatch, got %v", err)
+		}
+	}()
+
+	relation.Compose(succ, lessEqual)
+}
+
+func TestSetOperations(t *testing.T) {
+	union := relation.Union(lessThan, equality)
+	intersection := relation.Intersection(lessEqual, equality)
+	difference := relation.Difference(lessEqual, equality)
+
+	for _, x := range numbers.Elements() {
+		for _, y := range numbers.Elements() {
+			if union.ContainsRelation(x, y) != lessEqual.ContainsRelation(x, y) {
+				t.Fatalf("Expected < ∪ = to be ≤ at (%v, %v)", x, y)
+			}
+
+			if intersection.ContainsRelation(x, y) != equality.ContainsRelation(x, y) {
+				t.Fatalf("Expected ≤ ∩ = to be = at (%v, %v)", x, y)
+			}
+
+			if difference.ContainsRelation(x, y) != lessThan.ContainsRelation(x, y) {
+				t.Fatalf("Expected ≤ \\ = to be < at (%v, %v)", x, y)
+			}
+		}
+	}
+}
+
+func TestUncheckedViews(t *testing.T) {
+	// predicate backed relations do not check membership
+	if !lessEqual.ContainsRelation(100, 200) || relation.Complement(lessEqual).ContainsRelation(100, 200) {
+		t.Errorf("Expected the predicate to decide (100, 200), outside the universe")
+	}
+
+	if _, err := relation.TryContainsRelation(lessEqual, 100, 200); !errors.Is(err, relation.ErrNotInUniverse) {
+		t.Errorf("Expected TryContainsRelation to check membership, got %v", err)
+	}
+
+	// while views of physical relations panic in their operands
+	s := set.WithElements(0, 1, 2)
+	b := relation.New(s)
+	b.AddRelation(0, 1)
+
+	views := map[string]relation.AbstractInterface{
+		"Converse":     relation.Converse(b),
+		"Complement":   relation.Complement(b),
+		"Union":        relation.Union(b, b),
+		"Intersection": relation.Intersection(b, b),
+		"Difference":   relation.Difference(b, b),
+		"Compose":      relation.Compose(b, b),
+	}
+
+	for name, v := range views {
+		func() {
+			defer func() {
+				if err, ok := recover().(error); !ok || !errors.Is(err, relation.ErrNotInUniverse) {
+					t.Errorf("%s: Expected a panic wrapping ErrNotInUniverse, got %v", name, err)
+				}
+			}()
+
+			v.ContainsRelation(0, 7)
+		}()
+	}
+}
+
+func TestMaterialize(t *testing.T) {
+	b := relation.Materialize(lessEqual)
+
+	if b.Universe() != lessEqual.Universe() {
+		t.Errorf("Expected the materialized relation to share the universe")
+	}
+
+	if !b.ContainsRelation(3, 4) || b.ContainsRelation(4, 3) {
+		t.Errorf("Expected the materialized relation to be ≤")
+	}
+
+	b.RemoveRelation(3, 4)
+
+	if !lessEqual.ContainsRelation(3, 4) {
+		t.Errorf("Expected modifying the materialized relation to leave the original intact")
+	}
+}
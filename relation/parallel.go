@@ -0,0 +1,263 @@
+package relation
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// --- Parallel Snapshots {{{
+
+// represented snapshots b from its representation, rather than by
+// calls to ContainsRelation, or returns nil if b must be consulted pair
+// by pair. The operands of a composition are snapshot by graphParallel.
+func represented(ctx context.Context, b AbstractInterface, workers int) (*graph, error) {
+	switch r := b.(type) {
+	case *denseRelation:
+		return r.graph(), ctx.Err()
+	case *binaryRelation:
+		if g := newGraph(b.Universe().Elements()); g.fill(r) {
+			return g, ctx.Err()
+		}
+	case *composition:
+		gr, err := graphParallel(ctx, r.r, workers)
+		if err != nil {
+			return nil, err
+		}
+
+		gs, err := graphParallel(ctx, r.s, workers)
+		if err != nil {
+			return nil, err
+		}
+
+		if g := composeGraphs(gr, gs); g != nil {
+			return g, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// fillRow fills the row of x in g by consulting b. It returns false,
+// leaving the row incomplete, if ctx is done or *halted is set before
+// the row is filled.
+func (g *graph) fillRow(ctx context.Context, b AbstractInterface, x int, halted *int32) bool {
+	ex := g.elems[x]
+
+	for y, ey := range g.elems {
+		if atomic.LoadInt32(halted) != 0 {
+			return false
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		default:
+		}
+
+		if b.ContainsRelation(ex, ey) {
+			g.rows[x].add(y)
+		}
+	}
+
+	return true
+}
+
+// graphParallel takes a snapshot of b as graphOf does, but consults b
+// from workers goroutines at once, each filling a share of the rows.
+// It stops early, returning ctx.Err(), if ctx is done, even during a
+// row.
+func graphParallel(ctx context.Context, b AbstractInterface, workers int) (*graph, error) {
+	if g, err := represented(ctx, b, workers); g != nil || err != nil {
+		return g, err
+	}
+
+	g := newGraph(b.Universe().Elements())
+
+	var halted int32
+
+	// each row is filled by a single worker, so no two write to the
+	// same bitset; a row is abandoned only if ctx is done
+	err := parallel(ctx, len(g.elems), workers, func(x int) bool {
+		return g.fillRow(ctx, b, x, &halted)
+	})
+
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	g.reindex()
+	return g, nil
+}
+
+// parallel calls fn(i) for each i in [0, n) from workers goroutines,
+// GOMAXPROCS if workers is not positive. It stops early if fn returns
+// false, returning nil, or if ctx is done, returning ctx.Err().
+func parallel(ctx context.Context, n, workers int, fn func(i int) bool) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// the work is handed out an index at a time, as rows may differ
+	// greatly in cost
+	var (
+		next, finished int64
+		stopped        int32
+	)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for atomic.LoadInt32(&stopped) == 0 && ctx.Err() == nil {
+				i := int(atomic.AddInt64(&next, 1) - 1)
+				if i >= n {
+					return
+				}
+
+				if !fn(i) {
+					atomic.StoreInt32(&stopped, 1)
+				}
+
+				atomic.AddInt64(&finished, 1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if stopped == 1 || finished == int64(n) {
+		return nil
+	}
+
+	return ctx.Err()
+}
+
+// search looks for a counterexample in b from workers goroutines,
+// calling check with each pair of vertices x ≥ y of a snapshot of b,
+// and returning the first *Violation found, after which every worker
+// stops. check must read only the rows of x and y.
+//
+// If b must be consulted pair by pair, the workers fill the rows of
+// the snapshot, and each pair is checked as soon as both its rows are
+// filled, so that a counterexample is found without waiting for the
+// whole snapshot.
+func search(ctx context.Context, b AbstractInterface, workers int, check func(g *graph, x, y int) *Violation) (*Violation, error) {
+	g, err := represented(ctx, b, workers)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu     sync.Mutex
+		found  *Violation
+		halted int32
+	)
+
+	// report records v if it is the first found, and halts the workers
+	report := func(v *Violation) {
+		mu.Lock()
+		if found == nil {
+			found = v
+		}
+		mu.Unlock()
+
+		atomic.StoreInt32(&halted, 1)
+	}
+
+	if g != nil {
+		err = parallel(ctx, len(g.elems), workers, func(x int) bool {
+			for y := 0; y <= x; y++ {
+				if v := check(g, x, y); v != nil {
+					report(v)
+					return false
+				}
+			}
+
+			return true
+		})
+	} else {
+		g = newGraph(b.Universe().Elements())
+
+		// filled[x] is set once the row of x is complete; of two rows
+		// filled at once, at least one worker sees the other's flag,
+		// so every pair is checked
+		filled := make([]int32, len(g.elems))
+
+		err = parallel(ctx, len(g.elems), workers, func(x int) bool {
+			if !g.fillRow(ctx, b, x, &halted) {
+				return false
+			}
+
+			atomic.StoreInt32(&filled[x], 1)
+
+			for y := range filled {
+				if atomic.LoadInt32(&filled[y]) == 0 {
+					continue
+				}
+
+				if v := check(g, x, y); v != nil {
+					report(v)
+					return false
+				}
+			}
+
+			return true
+		})
+	}
+
+	if found != nil {
+		return found, nil
+	}
+
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	return nil, err
+}
+
+// --- }}}
+
+// --- Parallel Property Checks {{{
+
+// The property checks in this section are the parallel forms of those
+// of the same name, for large universes or expensive relations. Each
+// consults b from workers goroutines at once (GOMAXPROCS if workers is
+// not positive), each filling a share of the rows of a snapshot, and
+// checks each pair of elements as soon as both their rows are filled.
+// Every goroutine stops as soon as a counterexample is found, so that
+// a relation which is far from having the property need not be
+// consulted in full. The ContainsRelation method of b must be safe for
+// concurrent use.
+//
+// The counterexample returned is the first found, and need not be that
+// returned by the sequential form. If ctx is done before the check
+// completes, ctx.Err() is returned; the goroutines stop after their
+// current call to ContainsRelation.
+
+// CompleteParallel is the parallel form of CompleteViolation.
+func CompleteParallel(ctx context.Context, b AbstractInterface, workers int) (*Violation, error) {
+	return search(ctx, b, workers, (*graph).incomparablePair)
+}
+
+// TransitiveParallel is the parallel form of TransitiveViolation.
+func TransitiveParallel(ctx context.Context, b AbstractInterface, workers int) (*Violation, error) {
+	return search(ctx, b, workers, (*graph).intransitivePair)
+}
+
+// AntiSymmetricParallel is the parallel form of AntiSymmetricViolation.
+func AntiSymmetricParallel(ctx context.Context, b AbstractInterface, workers int) (*Violation, error) {
+	return search(ctx, b, workers, (*graph).mutualPair)
+}
+
+// --- }}}
@@ -0,0 +1,111 @@
+package relation_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+func TestParallelProperties(t *testing.T) {
+	ctx := context.Background()
+
+	for _, workers := range []int{0, 1, 4} {
+		if v, err := relation.CompleteParallel(ctx, lessEqual, workers); v != nil || err != nil {
+			t.Errorf("Expected ≤ to be complete, got %v, %v", v, err)
+		}
+
+		if v, err := relation.TransitiveParallel(ctx, lessEqual, workers); v != nil || err != nil {
+			t.Errorf("Expected ≤ to be transitive, got %v, %v", v, err)
+		}
+
+		if v, err := relation.AntiSymmetricParallel(ctx, lessEqual, workers); v != nil || err != nil {
+			t.Errorf("Expected ≤ to be antisymmetric, got %v, %v", v, err)
+		}
+
+		if v, err := relation.CompleteParallel(ctx, equality, workers); err != nil || v == nil || v.Property != "complete" {
+			t.Errorf("Expected equality not to be complete, got %v, %v", v, err)
+		}
+
+		if v, err := relation.AntiSymmetricParallel(ctx, relation.Complement(equality), workers); err != nil || v == nil {
+			t.Errorf("Expected ≠ not to be antisymmetric, got %v, %v", v, err)
+		}
+
+		b := chain(50)
+		v, err := relation.TransitiveParallel(ctx, b, workers)
+		if err != nil || v == nil || len(v.Elements) != 3 {
+			t.Fatalf("Expected a chain not to be transitive, got %v, %v", v, err)
+		}
+
+		x, y, z := v.Elements[0], v.Elements[1], v.Elements[2]
+		if !b.ContainsRelation(x, y) || !b.ContainsRelation(y, z) || b.ContainsRelation(x, z) {
+			t.Errorf("Expected %v to witness intransitivity", v.Elements)
+		}
+	}
+}
+
+func TestParallelEarlyExit(t *testing.T) {
+	ctx := context.Background()
+	n := int64(numbers.Cardinality())
+
+	for _, workers := range []int{1, 4} {
+		// > is not complete, as no element is related to itself, which
+		// is evident once a single row is filled
+		var calls int64
+		v, err := relation.CompleteParallel(ctx, relation.Complement(counting(&calls)), workers)
+		if err != nil || v == nil {
+			t.Fatalf("Expected > not to be complete, got %v, %v", v, err)
+		}
+
+		if atomic.LoadInt64(&calls) > int64(workers)*n {
+			t.Errorf("%d workers: Expected the check to stop within a row each, made %d calls", workers, calls)
+		}
+
+		// the same is true of a composition, whose operands are
+		// snapshot in full, but only once
+		calls = 0
+		c := relation.Compose(counting(&calls), relation.Complement(counting(&calls)))
+		if v, err := relation.AntiSymmetricParallel(ctx, c, workers); err != nil || v == nil {
+			t.Fatalf("Expected ≤;> not to be antisymmetric, got %v, %v", v, err)
+		}
+
+		if atomic.LoadInt64(&calls) != 2*n*n {
+			t.Errorf("%d workers: Expected each operand to be snapshot once, made %d calls", workers, calls)
+		}
+	}
+}
+
+func TestParallelCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := make(chan struct{}, 1)
+	slow := relation.NewFunctionBinaryRelation(numbers, func(x, y set.Element) bool {
+		select {
+		case calls <- struct{}{}:
+		default:
+		}
+		<-ctx.Done()
+		return true
+	})
+
+	go func() {
+		<-calls
+		cancel()
+	}()
+
+	if _, err := relation.TransitiveParallel(ctx, slow, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the check to be canceled, got %v", err)
+	}
+
+	// the operands of a composition are snapshot under the context
+	if _, err := relation.TransitiveParallel(ctx, relation.Compose(slow, slow), 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the composition to be canceled, got %v", err)
+	}
+
+	if _, err := relation.CompleteParallel(ctx, lessEqual, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled context to be reported, got %v", err)
+	}
+}
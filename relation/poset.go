@@ -0,0 +1,405 @@
+package relation
+
+import (
+	"fmt"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Order Toolkit {{{
+
+// The functions in this file treat b as an order, written ≤, where
+//
+//	x ≤ y ⇔ x = y or xBy
+//	x < y ⇔ x ≤ y and not y ≤ x
+//
+// so that they apply equally to reflexive orders such as ≤ and strict
+// orders such as <. They are intended for PartialOrders; over a
+// Preorder, elements which are equivalent but not equal are treated
+// alike, and any one of them may be returned where a unique element
+// is expected.
+//
+// Subsets given to these functions must be contained in the universe
+// of b, otherwise they panic with a *UniverseError.
+
+// leq reports whether i ≤ j.
+func (g *graph) leq(i, j int) bool {
+	return i == j || g.rows[i].has(j)
+}
+
+// less reports whether i < j.
+func (g *graph) less(i, j int) bool {
+	return g.leq(i, j) && !g.leq(j, i)
+}
+
+// vertices finds the vertices of the elements of s, or panics.
+func (g *graph) vertices(op string, s set.Interface) []int {
+	elems := s.Elements()
+	vs := make([]int, len(elems))
+
+	for k, e := range elems {
+		i, ok := g.index[e]
+		if !ok {
+			panic(&UniverseError{Op: op, Element: e, Position: 1})
+		}
+
+		vs[k] = i
+	}
+
+	return vs
+}
+
+// extremal constructs the set of i ∈ vs such that no j ∈ vs has
+// (j < i if minimal, i < j otherwise).
+func (g *graph) extremal(vs []int, minimal bool) set.Interface {
+	s := set.New()
+
+Candidates:
+	for _, i := range vs {
+		for _, j := range vs {
+			if (minimal && g.less(j, i)) || (!minimal && g.less(i, j)) {
+				continue Candidates
+			}
+		}
+
+		s.Add(g.elems[i])
+	}
+
+	return s
+}
+
+// Minimal constructs the set of minimal elements of s: those m ∈ s
+// such that there is no x ∈ s with x < m.
+func Minimal(b AbstractInterface, s set.Interface) set.Interface {
+	g := graphOf(b)
+	return g.extremal(g.vertices("Minimal", s), true)
+}
+
+// Maximal constructs the set of maximal elements of s: those m ∈ s
+// such that there is no x ∈ s with m < x.
+func Maximal(b AbstractInterface, s set.Interface) set.Interface {
+	g := graphOf(b)
+	return g.extremal(g.vertices("Maximal", s), false)
+}
+
+// bound returns some i ∈ vs such that i ≤ j for every j ∈ vs (if least),
+// or j ≤ i for every j ∈ vs (otherwise).
+func (g *graph) bound(vs []int, least bool) (int, bool) {
+Candidates:
+	for _, i := range vs {
+		for _, j := range vs {
+			if (least && !g.leq(i, j)) || (!least && !g.leq(j, i)) {
+				continue Candidates
+			}
+		}
+
+		return i, true
+	}
+
+	return -1, false
+}
+
+// Least returns the least element of s, l ∈ s such that l ≤ x for
+// any x ∈ s, and whether one exists.
+func Least(b AbstractInterface, s set.Interface) (set.Element, bool) {
+	g := graphOf(b)
+	return g.element(g.bound(g.vertices("Least", s), true))
+}
+
+// Greatest returns the greatest element of s, g ∈ s such that x ≤ g
+// for any x ∈ s, and whether one exists.
+func Greatest(b AbstractInterface, s set.Interface) (set.Element, bool) {
+	g := graphOf(b)
+	return g.element(g.bound(g.vertices("Greatest", s), false))
+}
+
+// element maps the result of bound to an element.
+func (g *graph) element(i int, ok bool) (set.Element, bool) {
+	if !ok {
+		return nil, false
+	}
+
+	return g.elems[i], true
+}
+
+// bounds returns the i ∈ X such that j ≤ i for every j ∈ vs (if upper),
+// or i ≤ j for every j ∈ vs (otherwise).
+func (g *graph) bounds(vs []int, upper bool) []int {
+	var bs []int
+
+Candidates:
+	for i := range g.elems {
+		for _, j := range vs {
+			if (upper && !g.leq(j, i)) || (!upper && !g.leq(i, j)) {
+				continue Candidates
+			}
+		}
+
+		bs = append(bs, i)
+	}
+
+	return bs
+}
+
+// UpperBounds constructs the set of upper bounds of s: those u ∈ X
+// such that x ≤ u for any x ∈ s.
+func UpperBounds(b AbstractInterface, s set.Interface) set.Interface {
+	g := graphOf(b)
+	return set.With(g.elements(g.bounds(g.vertices("UpperBounds", s), true)))
+}
+
+// LowerBounds constructs the set of lower bounds of s: those l ∈ X
+// such that l ≤ x for any x ∈ s.
+func LowerBounds(b AbstractInterface, s set.Interface) set.Interface {
+	g := graphOf(b)
+	return set.With(g.elements(g.bounds(g.vertices("LowerBounds", s), false)))
+}
+
+// Supremum returns the least upper bound of s, and whether it exists.
+func Supremum(b AbstractInterface, s set.Interface) (set.Element, bool) {
+	g := graphOf(b)
+	return g.element(g.bound(g.bounds(g.vertices("Supremum", s), true), true))
+}
+
+// Infimum returns the greatest lower bound of s, and whether it exists.
+func Infimum(b AbstractInterface, s set.Interface) (set.Element, bool) {
+	g := graphOf(b)
+	return g.element(g.bound(g.bounds(g.vertices("Infimum", s), false), false))
+}
+
+// --- }}}
+
+// --- Lattices {{{
+
+// Join returns x ∨ y, the Supremum of {x, y}, and whether it exists.
+func Join(b AbstractInterface, x, y set.Element) (set.Element, bool) {
+	return Supremum(b, set.WithElements(x, y))
+}
+
+// Meet returns x ∧ y, the Infimum of {x, y}, and whether it exists.
+func Meet(b AbstractInterface, x, y set.Element) (set.Element, bool) {
+	return Infimum(b, set.WithElements(x, y))
+}
+
+// Lattice checks that b is a PartialOrder in which every pair of
+// elements has a Join and a Meet. For example, ⊆ is a lattice on the
+// subsets of a set, with ∪ as join and ∩ as meet.
+func Lattice(b AbstractInterface) bool {
+	return LatticeViolation(b) == nil
+}
+
+// LatticeViolation returns the first counterexample to b being a
+// PartialOrder, or a pair (x, y) which has no Join or no Meet, or nil
+// if b is a Lattice.
+func LatticeViolation(b AbstractInterface) *Violation {
+	g := graphOf(b)
+	if v := g.partialOrderViolation(); v != nil {
+		return v
+	}
+
+	for x := range g.elems {
+		for y := x + 1; y < len(g.elems); y++ {
+			pair := []int{x, y}
+			ex, ey := g.elems[x], g.elems[y]
+
+			if _, ok := g.bound(g.bounds(pair, true), true); !ok {
+				return violate("a lattice", fmt.Sprintf("%v and %v have no join", ex, ey), ex, ey)
+			}
+
+			if _, ok := g.bound(g.bounds(pair, false), false); !ok {
+				return violate("a lattice", fmt.Sprintf("%v and %v have no meet", ex, ey), ex, ey)
+			}
+		}
+	}
+
+	return nil
+}
+
+// --- }}}
+
+// --- Chains and Antichains {{{
+
+// IsChain checks that every pair of elements of s is comparable:
+// x ≤ y or y ≤ x, for any x, y ∈ s.
+func IsChain(b AbstractInterface, s set.Interface) bool {
+	g := graphOf(b)
+	vs := g.vertices("IsChain", s)
+
+	for _, i := range vs {
+		for _, j := range vs {
+			if !g.leq(i, j) && !g.leq(j, i) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// IsAntichain checks that no two distinct elements of s are comparable:
+// not x ≤ y, for any x ≠ y ∈ s.
+func IsAntichain(b AbstractInterface, s set.Interface) bool {
+	g := graphOf(b)
+	vs := g.vertices("IsAntichain", s)
+
+	for _, i := range vs {
+		for _, j := range vs {
+			if i != j && g.leq(i, j) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// strict constructs the graph of <, closed under transitivity. If <
+// has a cycle, which it cannot if b is transitive, the cycle is
+// returned as a *Violation instead.
+func (g *graph) strict() (*graph, error) {
+	s := newGraph(g.elems)
+
+	for i := range g.elems {
+		for _, j := range g.succ[i] {
+			if g.less(i, j) {
+				s.rows[i].add(j)
+			}
+		}
+	}
+
+	s.reindex()
+	if v := s.cycleViolation("acyclic"); v != nil {
+		return nil, v
+	}
+
+	s.closeTransitive()
+	return s, nil
+}
+
+// LongestChain returns a chain x0 < x1 < ... < xk of greatest length,
+// listed in increasing order. Its length is the height of the order.
+// If < has a cycle, so that b is not an order, the cycle is returned
+// as a *Violation.
+func LongestChain(b AbstractInterface) ([]set.Element, error) {
+	s, err := graphOf(b).strict()
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(s.elems)
+	if n == 0 {
+		return nil, nil
+	}
+
+	// the components of < are single elements in reverse topological
+	// order, so the chains above x are known by the time we reach x
+	comps, _ := s.components()
+	height := make([]int, n)
+	next := make([]int, n)
+	top := comps[0][0]
+
+	for _, c := range comps {
+		x := c[0]
+		height[x], next[x] = 1, -1
+
+		for _, y := range s.succ[x] {
+			if height[y]+1 > height[x] {
+				height[x], next[x] = height[y]+1, y
+			}
+		}
+
+		if height[x] > height[top] {
+			top = x
+		}
+	}
+
+	var chain []int
+	for x := top; x >= 0; x = next[x] {
+		chain = append(chain, x)
+	}
+
+	return s.elements(chain), nil
+}
+
+// MaximumAntichain returns an antichain of greatest cardinality. Its
+// cardinality is the width of the order, which by Dilworth's theorem
+// is the least number of chains covering the universe. If < has a
+// cycle, the *Violation is returned as the error, as by LongestChain.
+func MaximumAntichain(b AbstractInterface) (set.Interface, error) {
+	s, err := graphOf(b).strict()
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(s.elems)
+
+	// maximum matching of the bipartite graph with an edge from the
+	// left copy of x to the right copy of y iff x < y
+	matchL, matchR := make([]int, n), make([]int, n)
+	for i := range matchL {
+		matchL[i], matchR[i] = -1, -1
+	}
+
+	var augment func(x int, seen []bool) bool
+	augment = func(x int, seen []bool) bool {
+		for _, y := range s.succ[x] {
+			if seen[y] {
+				continue
+			}
+			seen[y] = true
+
+			if matchR[y] < 0 || augment(matchR[y], seen) {
+				matchL[x], matchR[y] = y, x
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for x := 0; x < n; x++ {
+		augment(x, make([]bool, n))
+	}
+
+	// König's theorem: with Z the vertices reachable from unmatched
+	// left vertices by alternating paths, (L \ Z) ∪ (R ∩ Z) is a
+	// minimum vertex cover, and the elements with neither copy in the
+	// cover form a maximum antichain
+	visitedL, visitedR := make([]bool, n), make([]bool, n)
+
+	var queue []int
+	for x := 0; x < n; x++ {
+		if matchL[x] < 0 {
+			visitedL[x] = true
+			queue = append(queue, x)
+		}
+	}
+
+	for len(queue) > 0 {
+		x := queue[0]
+		queue = queue[1:]
+
+		for _, y := range s.succ[x] {
+			if visitedR[y] || matchL[x] == y {
+				continue
+			}
+			visitedR[y] = true
+
+			if z := matchR[y]; z >= 0 && !visitedL[z] {
+				visitedL[z] = true
+				queue = append(queue, z)
+			}
+		}
+	}
+
+	antichain := set.New()
+	for x := 0; x < n; x++ {
+		if visitedL[x] && !visitedR[x] {
+			antichain.Add(s.elems[x])
+		}
+	}
+
+	return antichain, nil
+}
+
+// --- }}}
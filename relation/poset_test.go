@@ -0,0 +1,142 @@
+package relation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+// divides constructs divisibility over the given integers
+func divides(elements ...set.Element) relation.AbstractInterface {
+	return relation.NewFunctionBinaryRelation(set.WithElements(elements...), func(x, y set.Element) bool {
+		return y.(int)%x.(int) == 0
+	})
+}
+
+func TestExtremalElements(t *testing.T) {
+	d := divides(2, 3, 4, 6, 9, 12)
+
+	if !relation.PartialOrder(d) {
+		t.Fatalf("Expected divisibility to be a partial order, got %v", relation.PartialOrderViolation(d))
+	}
+
+	if m := relation.Minimal(d, d.Universe()); !set.Equivalent(m, set.WithElements(2, 3)) {
+		t.Errorf("Expected the minimal elements to be {2, 3}, got %s", m)
+	}
+
+	if m := relation.Maximal(d, d.Universe()); !set.Equivalent(m, set.WithElements(9, 12)) {
+		t.Errorf("Expected the maximal elements to be {9, 12}, got %s", m)
+	}
+
+	if _, ok := relation.Least(d, d.Universe()); ok {
+		t.Errorf("Expected no least element")
+	}
+
+	if l, ok := relation.Least(d, set.WithElements(2, 4, 12)); !ok || l != 2 {
+		t.Errorf("Expected the least element of {2, 4, 12} to be 2, got %v", l)
+	}
+
+	if g, ok := relation.Greatest(d, set.WithElements(2, 4, 12)); !ok || g != 12 {
+		t.Errorf("Expected the greatest element of {2, 4, 12} to be 12, got %v", g)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	d := divides(1, 2, 3, 4, 6, 12)
+	s := set.WithElements(2, 3)
+
+	if u := relation.UpperBounds(d, s); !set.Equivalent(u, set.WithElements(6, 12)) {
+		t.Errorf("Expected the upper bounds of {2, 3} to be {6, 12}, got %s", u)
+	}
+
+	if l := relation.LowerBounds(d, s); !set.Equivalent(l, set.WithElements(1)) {
+		t.Errorf("Expected the lower bounds of {2, 3} to be {1}, got %s", l)
+	}
+
+	if sup, ok := relation.Supremum(d, s); !ok || sup != 6 {
+		t.Errorf("Expected the supremum of {2, 3} to be 6, got %v", sup)
+	}
+
+	if inf, ok := relation.Infimum(d, set.WithElements(4, 6)); !ok || inf != 2 {
+		t.Errorf("Expected the infimum of {4, 6} to be 2, got %v", inf)
+	}
+
+	defer func() {
+		if _, ok := recover().(*relation.UniverseError); !ok {
+			t.Errorf("Expected a subset outside of the universe to panic with a *UniverseError")
+		}
+	}()
+
+	relation.UpperBounds(d, set.WithElements(5))
+}
+
+func TestLattice(t *testing.T) {
+	d := divides(1, 2, 3, 4, 6, 12)
+
+	if !relation.Lattice(d) {
+		t.Fatalf("Expected the divisors of 12 to form a lattice, got %v", relation.LatticeViolation(d))
+	}
+
+	if j, ok := relation.Join(d, 4, 6); !ok || j != 12 {
+		t.Errorf("Expected 4 ∨ 6 = 12, got %v", j)
+	}
+
+	if m, ok := relation.Meet(d, 4, 6); !ok || m != 2 {
+		t.Errorf("Expected 4 ∧ 6 = 2, got %v", m)
+	}
+
+	if v := relation.LatticeViolation(divides(1, 2, 3)); v == nil {
+		t.Errorf("Expected {1, 2, 3} to not be a lattice, as 2 and 3 have no join")
+	}
+
+	if relation.Lattice(lessThan) {
+		t.Errorf("Expected < to not be a lattice, as it is not a partial order")
+	}
+}
+
+func TestChains(t *testing.T) {
+	d := divides(1, 2, 3, 4, 6, 12)
+
+	if !relation.IsChain(d, set.WithElements(1, 2, 4, 12)) || relation.IsChain(d, set.WithElements(2, 3)) {
+		t.Errorf("Expected {1, 2, 4, 12} to be a chain, and {2, 3} to not be")
+	}
+
+	if !relation.IsAntichain(d, set.WithElements(4, 6)) || relation.IsAntichain(d, set.WithElements(2, 4)) {
+		t.Errorf("Expected {4, 6} to be an antichain, and {2, 4} to not be")
+	}
+
+	chain, err := relation.LongestChain(d)
+	if err != nil || len(chain) != 4 || chain[0] != 1 || chain[3] != 12 {
+		t.Errorf("Expected a longest chain from 1 to 12 of length 4, got %v", chain)
+	}
+
+	a, err := relation.MaximumAntichain(d)
+	if err != nil || a.Cardinality() != 2 || !relation.IsAntichain(d, a) {
+		t.Errorf("Expected a maximum antichain of cardinality 2, got %s", a)
+	}
+
+	if a, _ := relation.MaximumAntichain(equality); a.Cardinality() != numbers.Cardinality() {
+		t.Errorf("Expected every element to form an antichain under =, got %d", a.Cardinality())
+	}
+
+	if chain, _ := relation.LongestChain(lessEqual); len(chain) != int(numbers.Cardinality()) {
+		t.Errorf("Expected ≤ to be a single chain, got %d elements", len(chain))
+	}
+
+	// a → b → c → a is not an order: < has a cycle
+	cycle := relation.New(set.WithElements("a", "b", "c"))
+	cycle.AddRelation("a", "b")
+	cycle.AddRelation("b", "c")
+	cycle.AddRelation("c", "a")
+
+	var v *relation.Violation
+	if chain, err := relation.LongestChain(cycle); !errors.As(err, &v) || v.Property != "acyclic" {
+		t.Errorf("Expected LongestChain to report the cycle, got %v, %v", chain, err)
+	}
+
+	if a, err := relation.MaximumAntichain(cycle); !errors.As(err, &v) || v.Property != "acyclic" {
+		t.Errorf("Expected MaximumAntichain to report the cycle, got %v, %v", a, err)
+	}
+}
@@ -0,0 +1,305 @@
+package relation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Properties {{{
+
+// The checks in this file take a snapshot of the relation, costing
+// n^2 calls to ContainsRelation where n = |Universe()|, and then
+// work on the snapshot with word-parallel bit operations.
+
+// Irreflexive checks the following condition:
+//
+//	not xBx for any x ∈ X ≡ Universe()
+func Irreflexive(b AbstractInterface) bool {
+	return IrreflexiveViolation(b) == nil
+}
+
+// IrreflexiveViolation returns an element x such that xBx,
+// or nil if b is Irreflexive.
+func IrreflexiveViolation(b AbstractInterface) *Violation {
+	for _, e := range b.Universe().Elements() {
+		if b.ContainsRelation(e, e) {
+			return violate("irreflexive", fmt.Sprintf("(%v, %v) ∈ B", e, e), e)
+		}
+	}
+
+	return nil
+}
+
+// Asymmetric checks the following condition:
+//
+//	xBy ⇒ not yBx for any x, y ∈ X ≡ Universe()
+//
+// An Asymmetric relation is necessarily Irreflexive.
+func Asymmetric(b AbstractInterface) bool {
+	return AsymmetricViolation(b) == nil
+}
+
+// AsymmetricViolation returns a pair (x, y) such that xBy and yBx,
+// or nil if b is Asymmetric. Note x may equal y.
+func AsymmetricViolation(b AbstractInterface) *Violation {
+	g := graphOf(b)
+
+	for x, succ := range g.succ {
+		for _, y := range succ {
+			if g.rows[y].has(x) {
+				ex, ey := g.elems[x], g.elems[y]
+				return violate("asymmetric", fmt.Sprintf("(%v, %v) ∈ B and (%v, %v) ∈ B", ex, ey, ey, ex), ex, ey)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Connex checks the following condition, also known as totality:
+//
+//	xBy or yBx for any x, y ∈ X ≡ Universe()
+//
+// Connex is a synonym for Complete.
+func Connex(b AbstractInterface) bool {
+	return Complete(b)
+}
+
+// ConnexViolation is a synonym for CompleteViolation.
+func ConnexViolation(b AbstractInterface) *Violation {
+	return CompleteViolation(b)
+}
+
+// Trichotomous checks the following condition:
+//
+//	exactly one of xBy, x = y, yBx for any x, y ∈ X ≡ Universe()
+//
+// For example, < on the naturals is Trichotomous.
+func Trichotomous(b AbstractInterface) bool {
+	return TrichotomousViolation(b) == nil
+}
+
+// TrichotomousViolation returns a pair (x, y) for which not exactly
+// one of xBy, x = y and yBx holds, or nil if b is Trichotomous.
+func TrichotomousViolation(b AbstractInterface) *Violation {
+	g := graphOf(b)
+
+	for x := range g.elems {
+		ex := g.elems[x]
+
+		if g.rows[x].has(x) {
+			return violate("trichotomous", fmt.Sprintf("(%v, %v) ∈ B", ex, ex), ex, ex)
+		}
+
+		for y := x + 1; y < len(g.elems); y++ {
+			ey := g.elems[y]
+
+			switch xy, yx := g.rows[x].has(y), g.rows[y].has(x); {
+			case xy && yx:
+				return violate("trichotomous", fmt.Sprintf("(%v, %v) ∈ B and (%v, %v) ∈ B", ex, ey, ey, ex), ex, ey)
+			case !xy && !yx:
+				return violate("trichotomous", fmt.Sprintf("neither (%v, %v) nor (%v, %v) ∈ B", ex, ey, ey, ex), ex, ey)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Euclidean checks the following condition:
+//
+//	(xBy and xBz) ⇒ yBz for any x, y, z ∈ X ≡ Universe()
+func Euclidean(b AbstractInterface) bool {
+	return EuclideanViolation(b) == nil
+}
+
+// EuclideanViolation returns a triple (x, y, z) such that xBy and xBz,
+// but not yBz, or nil if b is Euclidean.
+func EuclideanViolation(b AbstractInterface) *Violation {
+	g := graphOf(b)
+
+	// for xBy, we need every successor of x to be a successor of y
+	for x, succ := range g.succ {
+		for _, y := range succ {
+			if z := g.rows[x].firstMissing(g.rows[y]); z >= 0 {
+				ex, ey, ez := g.elems[x], g.elems[y], g.elems[z]
+				return violate("euclidean", fmt.Sprintf("(%v, %v) ∈ B and (%v, %v) ∈ B, but (%v, %v) ∉ B", ex, ey, ex, ez, ey, ez), ex, ey, ez)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Serial checks the following condition, also known as left-totality:
+//
+//	for any x ∈ X ≡ Universe(), there exists y ∈ X such that xBy
+func Serial(b AbstractInterface) bool {
+	return SerialViolation(b) == nil
+}
+
+// SerialViolation returns an element x related to no element,
+// or nil if b is Serial.
+func SerialViolation(b AbstractInterface) *Violation {
+	g := graphOf(b)
+
+	for x, succ := range g.succ {
+		if len(succ) == 0 {
+			ex := g.elems[x]
+			return violate("serial", fmt.Sprintf("(%v, y) ∉ B for any y", ex), ex)
+		}
+	}
+
+	return nil
+}
+
+// Dense checks the following condition:
+//
+//	xBy ⇒ there exists z ∈ X such that xBz and zBy, for any x, y ∈ X
+func Dense(b AbstractInterface) bool {
+	return DenseViolation(b) == nil
+}
+
+// DenseViolation returns a pair (x, y) such that xBy, but there is no
+// z with xBz and zBy, or nil if b is Dense.
+func DenseViolation(b AbstractInterface) *Violation {
+	g := graphOf(b)
+	cols := g.columns()
+
+	// for xBy, we need a successor of x which is a predecessor of y
+	for x, succ := range g.succ {
+		for _, y := range succ {
+			if g.rows[x].firstCommon(cols[y]) < 0 {
+				ex, ey := g.elems[x], g.elems[y]
+				return violate("dense", fmt.Sprintf("(%v, %v) ∈ B, but no z has (%v, z) ∈ B and (z, %v) ∈ B", ex, ey, ex, ey), ex, ey)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Acyclic checks that there are no x0, x1, ..., xk ∈ X ≡ Universe()
+// such that x0 B x1, x1 B x2, ..., xk B x0. Note that a single xBx is a
+// cycle, so an Acyclic relation is Irreflexive.
+func Acyclic(b AbstractInterface) bool {
+	return AcyclicViolation(b) == nil
+}
+
+// AcyclicViolation returns a cycle (x0, x1, ..., xk) such that
+// x0 B x1, ..., xk B x0, or nil if b is Acyclic.
+func AcyclicViolation(b AbstractInterface) *Violation {
+	return cycleViolation("acyclic", b)
+}
+
+// WellFounded checks that every non-empty subset S ⊆ X ≡ Universe()
+// has a B-minimal element: some m ∈ S such that not xBm for any x ∈ S.
+// Equivalently, there is no infinite descending chain ... x2 B x1 B x0.
+//
+// Over a finite universe a relation is WellFounded if and only if it
+// is Acyclic. For example, < is WellFounded, but ≤ is not.
+func WellFounded(b AbstractInterface) bool {
+	return WellFoundedViolation(b) == nil
+}
+
+// WellFoundedViolation returns a cycle (x0, x1, ..., xk) as in
+// AcyclicViolation, the elements of which form a subset without a
+// B-minimal element, or nil if b is WellFounded.
+func WellFoundedViolation(b AbstractInterface) *Violation {
+	return cycleViolation("well-founded", b)
+}
+
+// cycleViolation reports a cycle of b as a violation of property.
+func cycleViolation(property string, b AbstractInterface) *Violation {
+	return graphOf(b).cycleViolation(property)
+}
+
+// cycleViolation reports a cycle of g as a violation of property,
+// or returns nil if g is acyclic.
+func (g *graph) cycleViolation(property string) *Violation {
+	cycle := g.findCycle()
+	if cycle == nil {
+		return nil
+	}
+
+	elems := g.elements(append(cycle, cycle[0]))
+
+	steps := make([]string, len(elems))
+	for i, e := range elems {
+		steps[i] = fmt.Sprint(e)
+	}
+
+	return violate(property, fmt.Sprintf("cycle %s", strings.Join(steps, " B ")), elems[:len(cycle)]...)
+}
+
+// --- }}}
+
+// --- Composite Properties {{{
+
+// Equivalence checks that b is an equivalence relation, that is,
+// Reflexive, Symmetric and Transitive.
+func Equivalence(b AbstractInterface) bool {
+	return EquivalenceViolation(b) == nil
+}
+
+// EquivalenceViolation returns the first counterexample to b being
+// Reflexive, Symmetric and Transitive, or nil if b is an Equivalence.
+func EquivalenceViolation(b AbstractInterface) *Violation {
+	return graphOf(b).equivalenceViolation()
+}
+
+// equivalenceViolation is EquivalenceViolation of the snapshot g.
+func (g *graph) equivalenceViolation() *Violation {
+	if v := g.reflexiveViolation(); v != nil {
+		return v
+	}
+
+	if v := g.symmetricViolation(); v != nil {
+		return v
+	}
+
+	return g.transitiveViolation()
+}
+
+// Preorder checks that b is Reflexive and Transitive.
+func Preorder(b AbstractInterface) bool {
+	return PreorderViolation(b) == nil
+}
+
+// PreorderViolation returns the first counterexample to b being
+// Reflexive and Transitive, or nil if b is a Preorder.
+func PreorderViolation(b AbstractInterface) *Violation {
+	return graphOf(b).preorderViolation()
+}
+
+// preorderViolation is PreorderViolation of the snapshot g.
+func (g *graph) preorderViolation() *Violation {
+	if v := g.reflexiveViolation(); v != nil {
+		return v
+	}
+
+	return g.transitiveViolation()
+}
+
+// PartialOrder checks that b is Reflexive, AntiSymmetric and Transitive,
+// i.e., ≤ on the naturals, or ⊆ on the subsets of a set.
+func PartialOrder(b AbstractInterface) bool {
+	return PartialOrderViolation(b) == nil
+}
+
+// PartialOrderViolation returns the first counterexample to b being
+// Reflexive, AntiSymmetric and Transitive, or nil if b is a PartialOrder.
+func PartialOrderViolation(b AbstractInterface) *Violation {
+	return graphOf(b).partialOrderViolation()
+}
+
+// partialOrderViolation is PartialOrderViolation of the snapshot g.
+func (g *graph) partialOrderViolation() *Violation {
+	if v := g.preorderViolation(); v != nil {
+		return v
+	}
+
+	return g.antiSymmetricViolation()
+}
+
+// --- }}}
@@ -0,0 +1,124 @@
+package relation_test
+
+import (
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+var lessThan relation.AbstractInterface = relation.NewFunctionBinaryRelation(numbers, func(x, y set.Element) bool {
+	return x.(int) < y.(int)
+})
+
+func TestTransitiveIncomplete(t *testing.T) {
+	// divisibility is a partial order, but is not complete
+	divides := relation.NewFunctionBinaryRelation(set.WithElements(1, 2, 3, 4, 6, 12), func(x, y set.Element) bool {
+		return y.(int)%x.(int) == 0
+	})
+
+	if relation.Complete(divides) {
+		t.Fatal("Expected divisibility to be incomplete, consider (2, 3)")
+	}
+
+	if !relation.Transitive(divides) {
+		t.Errorf("Expected divisibility to be transitive, got %v", relation.TransitiveViolation(divides))
+	}
+
+	if relation.WeakOrder(divides) {
+		t.Errorf("Expected divisibility to not be a weak order")
+	}
+}
+
+func TestPropertyCatalog(t *testing.T) {
+	cases := []struct {
+		name     string
+		property func(relation.AbstractInterface) bool
+		holds    map[string]relation.AbstractInterface
+		fails    map[string]relation.AbstractInterface
+	}{
+		{"Irreflexive", relation.Irreflexive,
+			map[string]relation.AbstractInterface{"<": lessThan},
+			map[string]relation.AbstractInterface{"≤": lessEqual, "=": equality}},
+		{"Asymmetric", relation.Asymmetric,
+			map[string]relation.AbstractInterface{"<": lessThan},
+			map[string]relation.AbstractInterface{"≤": lessEqual}},
+		{"Connex", relation.Connex,
+			map[string]relation.AbstractInterface{"≤": lessEqual},
+			map[string]relation.AbstractInterface{"<": lessThan, "=": equality}},
+		{"Trichotomous", relation.Trichotomous,
+			map[string]relation.AbstractInterface{"<": lessThan},
+			map[string]relation.AbstractInterface{"≤": lessEqual, "=": equality}},
+		{"Euclidean", relation.Euclidean,
+			map[string]relation.AbstractInterface{"=": equality},
+			map[string]relation.AbstractInterface{"<": lessThan, "≤": lessEqual}},
+		{"Serial", relation.Serial,
+			map[string]relation.AbstractInterface{"≤": lessEqual, "=": equality},
+			map[string]relation.AbstractInterface{"<": lessThan}},
+		{"Dense", relation.Dense,
+			map[string]relation.AbstractInterface{"≤": lessEqual, "=": equality},
+			map[string]relation.AbstractInterface{"<": lessThan}},
+		{"WellFounded", relation.WellFounded,
+			map[string]relation.AbstractInterface{"<": lessThan},
+			map[string]relation.AbstractInterface{"≤": lessEqual}},
+		{"Acyclic", relation.Acyclic,
+			map[string]relation.AbstractInterface{"<": lessThan},
+			map[string]relation.AbstractInterface{"=": equality}},
+	}
+
+	for _, c := range cases {
+		for name, b := range c.holds {
+			if !c.property(b) {
+				t.Errorf("Expected %s to be %s", name, c.name)
+			}
+		}
+
+		for name, b := range c.fails {
+			if c.property(b) {
+				t.Errorf("Expected %s to not be %s", name, c.name)
+			}
+		}
+	}
+}
+
+func TestAcyclicViolation(t *testing.T) {
+	b := relation.New(set.WithElements("a", "b", "c", "d"))
+
+	b.AddRelation("a", "b")
+	b.AddRelation("b", "c")
+	b.AddRelation("c", "d")
+
+	if v := relation.AcyclicViolation(b); v != nil {
+		t.Fatalf("Expected a chain to be acyclic, got %v", v)
+	}
+
+	b.AddRelation("d", "b")
+
+	v := relation.AcyclicViolation(b)
+	if v == nil {
+		t.Fatal("Expected a cycle through b, c and d")
+	}
+
+	if len(v.Elements) != 3 {
+		t.Fatalf("Expected a cycle of length 3, got %v", v.Elements)
+	}
+
+	for i, x := range v.Elements {
+		y := v.Elements[(i+1)%len(v.Elements)]
+		if !b.ContainsRelation(x, y) {
+			t.Errorf("Expected (%v, %v) of the cycle %v to be related", x, y, v.Elements)
+		}
+	}
+}
+
+func TestEuclideanViolation(t *testing.T) {
+	b := relation.New(set.WithElements(1, 2, 3))
+
+	b.AddRelation(1, 2)
+	b.AddRelation(1, 3)
+
+	v := relation.EuclideanViolation(b)
+	if v == nil || len(v.Elements) != 3 || v.Elements[0] != 1 {
+		t.Fatalf("Expected a triple (1, y, z) violating the euclidean property, got %v", v)
+	}
+}
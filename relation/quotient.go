@@ -0,0 +1,169 @@
+package relation
+
+import "github.com/nlandolfi/set"
+
+// --- Quotient {{{
+
+// A Quotient is the partition X/~ of the universe X of an equivalence
+// relation ~ into its equivalence classes, [x] = {y ∈ X | x ~ y}.
+//
+// Each class has a canonical representative: its least member in the
+// order of set.Sorted, by fmt.Sprint, so that the same relation always
+// has the same representatives. Members of a class with the same
+// representation under fmt.Sprint are ordered arbitrarily.
+// A Quotient is a snapshot, it does not reflect later changes to the
+// relation from which it was constructed.
+type Quotient struct {
+	universe set.Interface
+
+	// classes[i] is the i-th class, and reps[i] its representative
+	classes []set.Interface
+	reps    []set.Element
+
+	// class maps each element of the universe to the index of its class
+	class map[set.Element]int
+}
+
+// NewQuotient constructs the Quotient of the universe of b by b. If b
+// is not an Equivalence, the *Violation is returned as the error.
+func NewQuotient(b AbstractInterface) (*Quotient, error) {
+	g := graphOf(b)
+	if v := g.equivalenceViolation(); v != nil {
+		return nil, v
+	}
+
+	q := &Quotient{
+		universe: b.Universe(),
+		class:    make(map[set.Element]int, len(g.elems)),
+	}
+
+	for _, e := range set.Sorted(q.universe) {
+		if _, seen := q.class[e]; seen {
+			continue
+		}
+
+		// e is the least element of its class, so represents it
+		x := g.index[e]
+		c := set.New()
+		for _, y := range g.succ[x] {
+			c.Add(g.elems[y])
+			q.class[g.elems[y]] = len(q.classes)
+		}
+
+		q.classes = append(q.classes, c)
+		q.reps = append(q.reps, e)
+	}
+
+	return q, nil
+}
+
+// EquivalenceClasses constructs the set of equivalence classes of b,
+// each of which is a set.Interface. If b is not an Equivalence, the
+// *Violation is returned as the error.
+func EquivalenceClasses(b AbstractInterface) (set.Interface, error) {
+	q, err := NewQuotient(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.Classes(), nil
+}
+
+// Universe returns the set which is partitioned by the Quotient.
+func (q *Quotient) Universe() set.Interface {
+	return q.universe
+}
+
+// Cardinality returns the number of equivalence classes, |X/~|.
+func (q *Quotient) Cardinality() uint {
+	return uint(len(q.classes))
+}
+
+// Classes constructs the set of equivalence classes, X/~.
+//
+// Note: The classes are copies, mutating them does not affect the Quotient.
+func (q *Quotient) Classes() set.Interface {
+	s := set.New()
+
+	for _, c := range q.classes {
+		s.Add(set.Clone(c))
+	}
+
+	return s
+}
+
+// Representatives constructs the set of canonical representatives,
+// containing exactly one element of each equivalence class.
+func (q *Quotient) Representatives() set.Interface {
+	return set.With(q.reps)
+}
+
+// ClassOf constructs the equivalence class of x, [x].
+//
+// ClassOf panics with a *UniverseError if x is not contained in the universe.
+func (q *Quotient) ClassOf(x set.Element) set.Interface {
+	return set.Clone(q.classes[q.lookup("ClassOf", x)])
+}
+
+// Representative returns the canonical representative of [x].
+//
+// Representative panics with a *UniverseError if x is not contained in
+// the universe.
+func (q *Quotient) Representative(x set.Element) set.Element {
+	return q.reps[q.lookup("Representative", x)]
+}
+
+// Equivalent reports whether x ~ y, that is, whether [x] = [y].
+//
+// Equivalent panics with a *UniverseError if either x or y is not
+// contained in the universe.
+func (q *Quotient) Equivalent(x, y set.Element) bool {
+	return q.lookup("Equivalent", x) == q.lookup("Equivalent", y)
+}
+
+// lookup finds the index of the class of x, or panics.
+func (q *Quotient) lookup(op string, x set.Element) int {
+	i, ok := q.class[x]
+	if !ok {
+		panic(&UniverseError{Op: op, Element: x, Position: 1})
+	}
+
+	return i
+}
+
+// --- }}}
+
+// --- Partitions {{{
+
+// FromPartition constructs the equivalence relation over universe whose
+// equivalence classes are the members of partition: x ~ y iff x and y
+// belong to the same member of partition.
+//
+// An error wrapping ErrNotPartition is returned if the members of
+// partition are not non-empty, pairwise disjoint sets whose union is
+// universe.
+func FromPartition(universe, partition set.Interface) (Interface, error) {
+	if err := set.CheckPartition(universe, partition); err != nil {
+		return nil, err
+	}
+
+	b := &binaryRelation{
+		universe:  universe,
+		relations: make(map[set.Element]map[set.Element]bool),
+	}
+
+	for _, p := range partition.Elements() {
+		elems := p.(set.Interface).Elements()
+
+		for _, x := range elems {
+			b.relations[x] = make(map[set.Element]bool, len(elems))
+			for _, y := range elems {
+				b.relations[x][y] = true
+			}
+		}
+	}
+
+	return b, nil
+}
+
+// --- }}}
@@ -0,0 +1,137 @@
+package relation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+// congruence is equivalence modulo 3 over {0, ..., 99}
+var congruence relation.AbstractInterface = relation.NewFunctionBinaryRelation(numbers, func(x, y set.Element) bool {
+	return x.(int)%3 == y.(int)%3
+})
+
+func TestQuotient(t *testing.T) {
+	q, err := relation.NewQuotient(congruence)
+	if err != nil {
+		t.Fatalf("Expected congruence modulo 3 to be an equivalence, got %v", err)
+	}
+
+	if q.Cardinality() != 3 {
+		t.Fatalf("Expected 3 equivalence classes, got %d", q.Cardinality())
+	}
+
+	c := q.ClassOf(4)
+	if c.Cardinality() != 33 || !c.Contains(1) || c.Contains(2) {
+		t.Errorf("Expected [4] to be {1, 4, 7, ..., 97}, got %s", c)
+	}
+
+	if q.Representative(4) != q.Representative(97) {
+		t.Errorf("Expected 4 and 97 to share a representative")
+	}
+
+	if r := q.Representative(5); r.(int)%3 != 2 {
+		t.Errorf("Expected the representative of [5] to be a member of [5], got %v", r)
+	}
+
+	if !q.Equivalent(3, 99) || q.Equivalent(3, 4) {
+		t.Errorf("Expected 3 ~ 99, but not 3 ~ 4")
+	}
+
+	if q.Representatives().Cardinality() != 3 {
+		t.Errorf("Expected one representative per class, got %s", q.Representatives())
+	}
+
+	classes, err := relation.EquivalenceClasses(congruence)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !classes.Contains(q.ClassOf(0)) {
+		t.Errorf("Expected the classes to contain [0]")
+	}
+
+	if _, err := relation.NewQuotient(lessEqual); err == nil {
+		t.Errorf("Expected ≤ to not be an equivalence")
+	}
+}
+
+func TestQuotientRepresentatives(t *testing.T) {
+	// the least member of each class by fmt.Sprint, so "11" < "2"
+	expected := set.WithElements(0, 1, 11)
+
+	for i := 0; i < 50; i++ {
+		q, err := relation.NewQuotient(congruence)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if reps := q.Representatives(); !set.Equivalent(reps, expected) {
+			t.Fatalf("Expected the representatives %s, got %s", expected, reps)
+		}
+
+		if r := q.Representative(5); r != 11 {
+			t.Fatalf("Expected the representative of [5] to be 11, got %v", r)
+		}
+	}
+}
+
+func TestQuotientSnapshot(t *testing.T) {
+	var calls int64
+	counted := relation.NewFunctionBinaryRelation(numbers, func(x, y set.Element) bool {
+		calls++
+		return congruence.ContainsRelation(x, y)
+	})
+
+	if _, err := relation.NewQuotient(counted); err != nil {
+		t.Fatal(err)
+	}
+
+	// one snapshot serves the check and the classes
+	if n := int64(numbers.Cardinality()); calls != n*n {
+		t.Errorf("Expected NewQuotient to make %d calls, got %d", n*n, calls)
+	}
+}
+
+func TestFromPartition(t *testing.T) {
+	u := set.WithElements(1, 2, 3, 4, 5)
+	p := set.WithElements(set.WithElements(1, 2), set.WithElements(3), set.WithElements(4, 5))
+
+	b, err := relation.FromPartition(u, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !relation.Equivalence(b) {
+		t.Fatalf("Expected the relation of a partition to be an equivalence, got %v", relation.EquivalenceViolation(b))
+	}
+
+	if !b.ContainsRelation(2, 1) || b.ContainsRelation(2, 3) {
+		t.Errorf("Expected 1 ~ 2, but not 2 ~ 3")
+	}
+
+	b.RemoveRelation(1, 2)
+	if !b.ContainsRelation(2, 1) {
+		t.Errorf("Expected removing (1, 2) to leave (2, 1)")
+	}
+
+	classes, _ := relation.EquivalenceClasses(relation.EquivalenceClosure(b))
+	if !set.Equivalent(classes, p) {
+		t.Errorf("Expected the classes %s to be the partition %s", classes, p)
+	}
+
+	invalid := []set.Interface{
+		set.WithElements(set.WithElements(1, 2), set.WithElements(2, 3, 4, 5)),
+		set.WithElements(set.WithElements(1, 2), set.WithElements(3, 4)),
+		set.WithElements(set.WithElements(1, 2, 3, 4, 5), set.New()),
+		set.WithElements(set.WithElements(1, 2, 3, 4, 5, 6)),
+	}
+
+	for _, p := range invalid {
+		if _, err := relation.FromPartition(u, p); !errors.Is(err, relation.ErrNotPartition) {
+			t.Errorf("Expected %s to not be a partition of %s, got %v", p, u, err)
+		}
+	}
+}
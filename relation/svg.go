@@ -0,0 +1,258 @@
+package relation
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- SVG {{{
+
+// dimensions of an SVG drawing, in pixels
+const (
+	svgMargin    = 20.0
+	svgNodeGap   = 24.0
+	svgLayerGap  = 72.0
+	svgNodeRy    = 16.0
+	svgMinNodeRx = 20.0
+	svgCharWidth = 7.0
+)
+
+// svgPalette colours the equivalence classes of DiagramOptions.Cluster
+var svgPalette = []string{
+	"#8dd3c7", "#ffffb3", "#bebada", "#fb8072", "#80b1d3", "#fdb462",
+	"#b3de69", "#fccde5", "#d9d9d9", "#bc80bd", "#ccebc5", "#ffed6f",
+}
+
+// WriteSVG draws b as a standalone SVG image to w, according to opts,
+// which may be nil. Unlike WriteDOT, no external program is needed:
+// the elements are laid out in layers, in the manner of Sugiyama,
+//
+//  1. each element is placed in the layer one below the lowest of
+//     the elements related to it, so that pairs point downwards
+//     (upwards for a Hasse diagram, whose minimal elements are at the
+//     bottom); the elements of a cycle share a layer;
+//  2. the elements of each layer are ordered to reduce crossings,
+//     by repeatedly moving each to the average position of its
+//     neighbours in the adjacent layer;
+//
+// and pairs are drawn as straight lines, which may pass behind the
+// elements of intermediate layers. Each class of opts.Cluster is drawn
+// in its own colour.
+//
+// An error is returned if opts.Cluster is not an Equivalence over the
+// universe of b, or writing to w fails.
+func WriteSVG(w io.Writer, b AbstractInterface, opts *DiagramOptions) error {
+	d, err := newDiagram(b, opts)
+	if err != nil {
+		return err
+	}
+
+	layers := d.layers()
+	d.order(layers)
+
+	n := len(d.labels)
+	x, y, rx := make([]float64, n), make([]float64, n), make([]float64, n)
+
+	for k, label := range d.labels {
+		rx[k] = math.Max(svgMinNodeRx, svgCharWidth*float64(len([]rune(label)))/2+10)
+	}
+
+	// the width of each layer, and of the widest
+	widths := make([]float64, len(layers))
+	width := 0.0
+	for l, layer := range layers {
+		for i, k := range layer {
+			if i > 0 {
+				widths[l] += svgNodeGap
+			}
+			widths[l] += 2 * rx[k]
+		}
+		width = math.Max(width, widths[l])
+	}
+
+	height := 2 * svgNodeRy
+	if len(layers) > 1 {
+		height += svgLayerGap * float64(len(layers)-1)
+	}
+
+	for l, layer := range layers {
+		row := l
+		if d.hasse {
+			row = len(layers) - 1 - l
+		}
+
+		left := svgMargin + (width-widths[l])/2
+		for _, k := range layer {
+			x[k] = left + rx[k]
+			y[k] = svgMargin + svgNodeRy + svgLayerGap*float64(row)
+			left += 2*rx[k] + svgNodeGap
+		}
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%.0f\" height=\"%.0f\" font-family=\"sans-serif\" font-size=\"12\">\n",
+		width+2*svgMargin, height+2*svgMargin)
+	fmt.Fprintf(&buf, "<title>%s</title>\n", html.EscapeString(d.name))
+
+	marker := ""
+	if !d.hasse {
+		buf.WriteString("<defs><marker id=\"arrow\" viewBox=\"0 0 10 10\" refX=\"10\" refY=\"5\" markerWidth=\"8\" markerHeight=\"8\" orient=\"auto\"><path d=\"M0,0 L10,5 L0,10 z\"/></marker></defs>\n")
+		marker = ` marker-end="url(#arrow)"`
+	}
+
+	buf.WriteString("<g stroke=\"black\" fill=\"none\">\n")
+
+	for _, e := range d.edges {
+		i, j := e[0], e[1]
+		dx, dy := x[j]-x[i], y[j]-y[i]
+
+		// leave and enter each ellipse at its boundary
+		si := 1 / math.Hypot(dx/rx[i], dy/svgNodeRy)
+		sj := 1 / math.Hypot(dx/rx[j], dy/svgNodeRy)
+
+		fmt.Fprintf(&buf, "<line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\"%s/>\n",
+			x[i]+si*dx, y[i]+si*dy, x[j]-sj*dx, y[j]-sj*dy, marker)
+	}
+
+	for k, loop := range d.loop {
+		if loop {
+			fmt.Fprintf(&buf, "<path d=\"M%.1f,%.1f A10,10 0 1,1 %.1f,%.1f\"%s/>\n",
+				x[k]-8, y[k]-svgNodeRy+2, x[k]+8, y[k]-svgNodeRy+2, marker)
+		}
+	}
+
+	buf.WriteString("</g>\n")
+
+	for k, label := range d.labels {
+		fill := "white"
+		if c := d.class[k]; c >= 0 {
+			fill = svgPalette[c%len(svgPalette)]
+		}
+
+		fmt.Fprintf(&buf, "<ellipse cx=\"%.1f\" cy=\"%.1f\" rx=\"%.1f\" ry=\"%.1f\" fill=\"%s\" stroke=\"black\"/>\n",
+			x[k], y[k], rx[k], svgNodeRy, fill)
+		fmt.Fprintf(&buf, "<text x=\"%.1f\" y=\"%.1f\" text-anchor=\"middle\" dominant-baseline=\"central\">%s</text>\n",
+			x[k], y[k], html.EscapeString(label))
+	}
+
+	buf.WriteString("</svg>\n")
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// layers assigns each vertex of d to a layer: the sources of the
+// condensation of d are in layer 0, and every other vertex is in the
+// layer one below its lowest predecessor outside its component.
+func (d *diagram) layers() [][]int {
+	n := len(d.labels)
+	if n == 0 {
+		return nil
+	}
+
+	// components only consults the successors of g
+	g := &graph{elems: make([]set.Element, n), succ: make([][]int, n)}
+	for _, e := range d.edges {
+		g.succ[e[0]] = append(g.succ[e[0]], e[1])
+	}
+
+	comps, comp := g.components()
+
+	// the components are in reverse topological order, so visit them
+	// backwards to see each before its successors
+	depth := make([]int, len(comps))
+	deepest := 0
+
+	for c := len(comps) - 1; c >= 0; c-- {
+		if depth[c] > deepest {
+			deepest = depth[c]
+		}
+
+		for _, v := range comps[c] {
+			for _, w := range g.succ[v] {
+				if e := comp[w]; e != c && depth[e] <= depth[c] {
+					depth[e] = depth[c] + 1
+				}
+			}
+		}
+	}
+
+	layers := make([][]int, deepest+1)
+
+	for v := 0; v < n; v++ {
+		l := depth[comp[v]]
+		layers[l] = append(layers[l], v)
+	}
+
+	return layers
+}
+
+// order permutes the vertices of each layer to reduce the crossings
+// of edges between adjacent layers, by the barycenter heuristic.
+func (d *diagram) order(layers [][]int) {
+	n := len(d.labels)
+
+	layer := make([]int, n)
+	position := make([]float64, n)
+	neighbours := make([][]int, n)
+
+	for l, vs := range layers {
+		for p, v := range vs {
+			layer[v], position[v] = l, float64(p)
+		}
+	}
+
+	for _, e := range d.edges {
+		neighbours[e[0]] = append(neighbours[e[0]], e[1])
+		neighbours[e[1]] = append(neighbours[e[1]], e[0])
+	}
+
+	// sweep arranges layer l by the neighbours of its vertices in the
+	// adjacent layer l+dir
+	sweep := func(l, dir int) {
+		vs := layers[l]
+		bary := make(map[int]float64, len(vs))
+
+		for _, v := range vs {
+			sum, count := 0.0, 0
+			for _, w := range neighbours[v] {
+				if layer[w] == l+dir {
+					sum += position[w]
+					count++
+				}
+			}
+
+			if count > 0 {
+				bary[v] = sum / float64(count)
+			} else {
+				bary[v] = position[v]
+			}
+		}
+
+		sort.SliceStable(vs, func(i, j int) bool {
+			return bary[vs[i]] < bary[vs[j]]
+		})
+
+		for p, v := range vs {
+			position[v] = float64(p)
+		}
+	}
+
+	for round := 0; round < 4; round++ {
+		for l := 1; l < len(layers); l++ {
+			sweep(l, -1)
+		}
+		for l := len(layers) - 2; l >= 0; l-- {
+			sweep(l, +1)
+		}
+	}
+}
+
+// --- }}}
@@ -0,0 +1,65 @@
+package relation_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/nlandolfi/set/relation"
+)
+
+func TestWriteSVG(t *testing.T) {
+	d := divides(1, 2, 3, 4, 6, 12)
+
+	var buf bytes.Buffer
+	if err := relation.WriteSVG(&buf, d, &relation.DiagramOptions{Hasse: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the image must be well formed
+	decoder := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Expected well formed SVG, got %v:\n%s", err, buf.String())
+		}
+	}
+
+	s := buf.String()
+
+	if got := strings.Count(s, "<ellipse"); got != 6 {
+		t.Errorf("Expected 6 elements to be drawn, got %d", got)
+	}
+
+	// 1-2, 1-3, 2-4, 2-6, 3-6, 4-12, 6-12
+	if got := strings.Count(s, "<line"); got != 7 {
+		t.Errorf("Expected 7 lines in the Hasse diagram, got %d", got)
+	}
+
+	if strings.Contains(s, "marker-end") {
+		t.Errorf("Expected the lines of a Hasse diagram to be undirected")
+	}
+
+	var again bytes.Buffer
+	relation.WriteSVG(&again, d, &relation.DiagramOptions{Hasse: true})
+	if again.String() != s {
+		t.Errorf("Expected the drawing to be reproducible")
+	}
+}
+
+func TestWriteSVGCycle(t *testing.T) {
+	d := divides(1, 2, 3)
+	c := relation.SymmetricClosure(d)
+
+	var buf bytes.Buffer
+	if err := relation.WriteSVG(&buf, c, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Count(buf.String(), "marker-end"); got != 7 {
+		t.Errorf("Expected 4 arrows and 3 loops, got %d", got)
+	}
+}
@@ -0,0 +1,236 @@
+package relation
+
+import (
+	"container/heap"
+	"math/big"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Topological Sort {{{
+
+// The functions in this file read xBy, for x ≠ y, as "x must precede
+// y", e.g., "x is a dependency of y". Pairs (x, x) are ignored, so
+// that both ≤ and < have the usual ascending order as their only
+// linear extension.
+//
+// If the pairs of distinct elements of b form a cycle, there is no
+// such ordering, and the functions return a *Violation of acyclicity
+// whose Elements are the cycle.
+
+// dependencies snapshots b without its pairs (x, x), and reports a
+// cycle of the remaining pairs as a *Violation.
+func dependencies(b AbstractInterface) (*graph, error) {
+	g := graphOf(b)
+
+	for i := range g.rows {
+		g.rows[i].remove(i)
+	}
+	g.reindex()
+
+	if v := g.cycleViolation("acyclic"); v != nil {
+		return nil, v
+	}
+
+	return g, nil
+}
+
+// TopologicalSort returns the elements of the universe of b ordered
+// such that x precedes y whenever xBy, i.e., a linear extension of b.
+// If b has a cycle, the *Violation is returned as the error.
+//
+// The order among elements which are not constrained by b is
+// unspecified, see TopologicalSortFunc.
+func TopologicalSort(b AbstractInterface) ([]set.Element, error) {
+	return TopologicalSortFunc(b, nil)
+}
+
+// TopologicalSortFunc is as TopologicalSort, but breaks ties using
+// less: of the elements which may come next, the least is chosen. The
+// result is deterministic if less is a strict total order on the
+// universe. A nil less breaks ties arbitrarily.
+func TopologicalSortFunc(b AbstractInterface, less func(x, y set.Element) bool) ([]set.Element, error) {
+	g, err := dependencies(b)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(g.elems)
+	indegree := make([]int, n)
+	for _, succ := range g.succ {
+		for _, y := range succ {
+			indegree[y]++
+		}
+	}
+
+	ready := &readyQueue{g: g, less: less}
+	for x := 0; x < n; x++ {
+		if indegree[x] == 0 {
+			heap.Push(ready, x)
+		}
+	}
+
+	order := make([]set.Element, 0, n)
+
+	for ready.Len() > 0 {
+		x := heap.Pop(ready).(int)
+		order = append(order, g.elems[x])
+
+		for _, y := range g.succ[x] {
+			if indegree[y]--; indegree[y] == 0 {
+				heap.Push(ready, y)
+			}
+		}
+	}
+
+	return order, nil
+}
+
+// readyQueue is a heap of the vertices which may come next in a
+// topological sort, ordered by less, or by vertex if less is nil.
+type readyQueue struct {
+	g        *graph
+	less     func(x, y set.Element) bool
+	vertices []int
+}
+
+func (q *readyQueue) Len() int { return len(q.vertices) }
+
+func (q *readyQueue) Less(i, j int) bool {
+	if q.less == nil {
+		return q.vertices[i] < q.vertices[j]
+	}
+
+	return q.less(q.g.elems[q.vertices[i]], q.g.elems[q.vertices[j]])
+}
+
+func (q *readyQueue) Swap(i, j int) {
+	q.vertices[i], q.vertices[j] = q.vertices[j], q.vertices[i]
+}
+
+func (q *readyQueue) Push(x interface{}) {
+	q.vertices = append(q.vertices, x.(int))
+}
+
+func (q *readyQueue) Pop() interface{} {
+	x := q.vertices[len(q.vertices)-1]
+	q.vertices = q.vertices[:len(q.vertices)-1]
+	return x
+}
+
+// --- }}}
+
+// --- Linear Extensions {{{
+
+// LinearExtensions calls fn with each linear extension of b, that is,
+// each ordering of the universe of b in which x precedes y whenever
+// xBy. Enumeration stops early if fn returns false. If b has a cycle,
+// the *Violation is returned as the error and fn is not called.
+//
+// The slice given to fn is reused between calls; fn must copy it to
+// retain it. There may be as many as n! linear extensions, where
+// n = |Universe()|.
+func LinearExtensions(b AbstractInterface, fn func([]set.Element) bool) error {
+	g, err := dependencies(b)
+	if err != nil {
+		return err
+	}
+
+	n := len(g.elems)
+	indegree := make([]int, n)
+	for _, succ := range g.succ {
+		for _, y := range succ {
+			indegree[y]++
+		}
+	}
+
+	placed := make([]bool, n)
+	order := make([]set.Element, 0, n)
+
+	// extend tries each possible next element in turn, and reports
+	// whether to continue the enumeration
+	var extend func() bool
+	extend = func() bool {
+		if len(order) == n {
+			return fn(order)
+		}
+
+		for x := 0; x < n; x++ {
+			if placed[x] || indegree[x] != 0 {
+				continue
+			}
+
+			placed[x] = true
+			order = append(order, g.elems[x])
+			for _, y := range g.succ[x] {
+				indegree[y]--
+			}
+
+			more := extend()
+
+			for _, y := range g.succ[x] {
+				indegree[y]++
+			}
+			order = order[:len(order)-1]
+			placed[x] = false
+
+			if !more {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	extend()
+	return nil
+}
+
+// CountLinearExtensions returns the number of linear extensions of b.
+// If b has a cycle, the *Violation is returned as the error.
+//
+// The count is computed over the downward closed subsets of b, of which
+// there are far fewer than linear extensions for most orders, but as
+// many as 2^n for an antichain of n elements.
+func CountLinearExtensions(b AbstractInterface) (*big.Int, error) {
+	g, err := dependencies(b)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(g.elems)
+	cols := g.columns()
+
+	// count(placed) is the number of ways to order the remaining elements
+	memo := make(map[string]*big.Int)
+
+	var count func(placed bitset, size int) *big.Int
+	count = func(placed bitset, size int) *big.Int {
+		if size == n {
+			return big.NewInt(1)
+		}
+
+		key := placed.key()
+		if c, ok := memo[key]; ok {
+			return c
+		}
+
+		c := new(big.Int)
+		for x := 0; x < n; x++ {
+			if placed.has(x) || !cols[x].subset(placed) {
+				continue
+			}
+
+			placed.add(x)
+			c.Add(c, count(placed, size+1))
+			placed.remove(x)
+		}
+
+		memo[key] = c
+		return c
+	}
+
+	return new(big.Int).Set(count(newBitset(n), 0)), nil
+}
+
+// --- }}}
@@ -0,0 +1,115 @@
+package relation_test
+
+import (
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+// dependencies constructs the relation of a small build:
+// "libc" precedes "net" and "io", which both precede "http"
+func dependencies() relation.Interface {
+	b := relation.New(set.WithElements("libc", "net", "io", "http"))
+
+	b.AddRelation("libc", "net")
+	b.AddRelation("libc", "io")
+	b.AddRelation("net", "http")
+	b.AddRelation("io", "http")
+
+	return b
+}
+
+func TestTopologicalSort(t *testing.T) {
+	b := dependencies()
+
+	order, err := relation.TopologicalSort(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	position := make(map[set.Element]int)
+	for i, e := range order {
+		position[e] = i
+	}
+
+	if len(order) != 4 || position["libc"] != 0 || position["http"] != 3 {
+		t.Errorf("Expected libc first and http last, got %v", order)
+	}
+
+	order, err = relation.TopologicalSortFunc(b, func(x, y set.Element) bool {
+		return x.(string) < y.(string)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if order[1] != "io" || order[2] != "net" {
+		t.Errorf("Expected ties to be broken alphabetically, got %v", order)
+	}
+
+	order, err = relation.TopologicalSort(lessEqual)
+	if err != nil {
+		t.Fatalf("Expected pairs (x, x) to be ignored, got %v", err)
+	}
+
+	for i, e := range order {
+		if e != i {
+			t.Fatalf("Expected ≤ to sort into ascending order, got %v", order)
+		}
+	}
+
+	b.AddRelation("http", "libc")
+
+	_, err = relation.TopologicalSort(b)
+	v, ok := err.(*relation.Violation)
+	if !ok || len(v.Elements) != 3 {
+		t.Errorf("Expected a cycle of length 3 to be reported, got %v", err)
+	}
+}
+
+func TestLinearExtensions(t *testing.T) {
+	b := dependencies()
+
+	var extensions [][]set.Element
+	err := relation.LinearExtensions(b, func(order []set.Element) bool {
+		extensions = append(extensions, append([]set.Element(nil), order...))
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(extensions) != 2 {
+		t.Fatalf("Expected 2 linear extensions, got %v", extensions)
+	}
+
+	if extensions[0][1] == extensions[1][1] {
+		t.Errorf("Expected net and io to be swapped between extensions, got %v", extensions)
+	}
+
+	count := 0
+	relation.LinearExtensions(relation.New(set.WithElements(1, 2, 3, 4)), func([]set.Element) bool {
+		count++
+		return count < 5
+	})
+
+	if count != 5 {
+		t.Errorf("Expected enumeration to stop after 5 extensions, got %d", count)
+	}
+
+	n, err := relation.CountLinearExtensions(b)
+	if err != nil || n.Int64() != 2 {
+		t.Errorf("Expected 2 linear extensions, got %v (%v)", n, err)
+	}
+
+	n, _ = relation.CountLinearExtensions(relation.New(set.WithElements(1, 2, 3, 4, 5, 6)))
+	if n.Int64() != 720 {
+		t.Errorf("Expected 6! linear extensions of an antichain of 6, got %v", n)
+	}
+
+	n, _ = relation.CountLinearExtensions(lessThan)
+	if n.Int64() != 1 {
+		t.Errorf("Expected < to have a single linear extension, got %v", n)
+	}
+}
@@ -0,0 +1,344 @@
+package relation
+
+import "github.com/nlandolfi/set"
+
+// --- Pairs {{{
+
+// EachPair calls fn with each pair (x, y) such that xBy, stopping
+// early if fn returns false.
+//
+// The pairs of an Enumerable relation are read from its
+// representation, by Pairs. Those of any other relation, e.g., one constructed by
+// NewFunctionBinaryRelation, are found lazily, testing one pair of the
+// universe at a time as the enumeration proceeds, so that an
+// enumeration which stops early need not examine the whole relation.
+func EachPair(b AbstractInterface, fn func(x, y set.Element) bool) {
+	if r, ok := b.(Enumerable); ok {
+		for _, p := range r.Pairs() {
+			if !fn(p.First, p.Second) {
+				return
+			}
+		}
+
+		return
+	}
+
+	universe := b.Universe().Elements()
+
+	for _, x := range universe {
+		for _, y := range universe {
+			if b.ContainsRelation(x, y) && !fn(x, y) {
+				return
+			}
+		}
+	}
+}
+
+// Pairs retrieves a slice of all pairs (x, y) such that xBy, each as a
+// set.Tuple. For an Enumerable relation, this is b.Pairs().
+func Pairs(b AbstractInterface) []set.Tuple {
+	if r, ok := b.(Enumerable); ok {
+		return r.Pairs()
+	}
+
+	pairs := make([]set.Tuple, 0)
+	EachPair(b, func(x, y set.Element) bool {
+		pairs = append(pairs, set.Tuple{First: x, Second: y})
+		return true
+	})
+
+	return pairs
+}
+
+// Size computes the number of related pairs, |B|. For an Enumerable
+// relation, this is b.Size().
+func Size(b AbstractInterface) uint {
+	if r, ok := b.(Enumerable); ok {
+		return r.Size()
+	}
+
+	size := uint(0)
+	EachPair(b, func(x, y set.Element) bool {
+		size++
+		return true
+	})
+
+	return size
+}
+
+// --- }}}
+
+// --- Neighbours {{{
+
+// successorsOf returns a function listing the y such that xBy.
+//
+// The successors of an Enumerable relation are read from its
+// representation; otherwise they are found by testing every element of
+// the universe, as each is needed, so that a traversal which stops
+// early need not examine the whole relation.
+func successorsOf(b AbstractInterface) func(x set.Element) []set.Element {
+	if r, ok := b.(Enumerable); ok {
+		return func(x set.Element) []set.Element {
+			return r.Successors(x).Elements()
+		}
+	}
+
+	universe := b.Universe().Elements()
+
+	return func(x set.Element) []set.Element {
+		ys := make([]set.Element, 0)
+		for _, y := range universe {
+			if b.ContainsRelation(x, y) {
+				ys = append(ys, y)
+			}
+		}
+		return ys
+	}
+}
+
+// checkElement panics with a *UniverseError if x is not contained in
+// the universe of b.
+func checkElement(op string, b AbstractInterface, x set.Element) {
+	if !b.Universe().Contains(x) {
+		panic(&UniverseError{Op: op, Element: x, Position: 1})
+	}
+}
+
+// Successors constructs the set of elements to which x is related,
+// {y ∈ X | xBy}. For an Enumerable relation, this is b.Successors(x).
+//
+// Successors panics with a *UniverseError if x is not contained in the
+// universe.
+func Successors(b AbstractInterface, x set.Element) set.Interface {
+	if r, ok := b.(Enumerable); ok {
+		return r.Successors(x)
+	}
+
+	checkElement("Successors", b, x)
+	return set.With(successorsOf(b)(x))
+}
+
+// Predecessors constructs the set of elements which are related to y,
+// {x ∈ X | xBy}. For an Enumerable relation, this is
+// b.Predecessors(y).
+//
+// Predecessors panics with a *UniverseError if y is not contained in
+// the universe.
+func Predecessors(b AbstractInterface, y set.Element) set.Interface {
+	if r, ok := b.(Enumerable); ok {
+		return r.Predecessors(y)
+	}
+
+	checkElement("Predecessors", b, y)
+
+	s := set.New()
+	for _, x := range b.Universe().Elements() {
+		if b.ContainsRelation(x, y) {
+			s.Add(x)
+		}
+	}
+
+	return s
+}
+
+// --- }}}
+
+// --- Traversal {{{
+
+// BreadthFirst calls fn with each element reachable from x, in order
+// of its distance from x: first x itself, at depth 0, then the
+// successors of x, at depth 1, and so on. Each element is visited
+// once. The traversal stops early if fn returns false.
+//
+// BreadthFirst panics with a *UniverseError if x is not contained in
+// the universe.
+func BreadthFirst(b AbstractInterface, x set.Element, fn func(y set.Element, depth int) bool) {
+	checkElement("BreadthFirst", b, x)
+	breadthFirst(b, x, func(y, _ set.Element, depth int) bool {
+		return fn(y, depth)
+	})
+}
+
+// breadthFirst is BreadthFirst, additionally reporting the element
+// from which each was first reached, nil for x itself.
+func breadthFirst(b AbstractInterface, x set.Element, fn func(y, from set.Element, depth int) bool) {
+	successors := successorsOf(b)
+
+	seen := map[set.Element]bool{x: true}
+	if !fn(x, nil, 0) {
+		return
+	}
+
+	frontier := []set.Element{x}
+
+	for depth := 1; len(frontier) > 0; depth++ {
+		var next []set.Element
+
+		for _, v := range frontier {
+			for _, w := range successors(v) {
+				if seen[w] {
+					continue
+				}
+
+				seen[w] = true
+				if !fn(w, v, depth) {
+					return
+				}
+
+				next = append(next, w)
+			}
+		}
+
+		frontier = next
+	}
+}
+
+// DepthFirst calls fn with each element reachable from x, in depth
+// first preorder: an element is visited before any of the elements
+// first reached through it, starting with x itself. Each element is
+// visited once. The traversal stops early if fn returns false.
+//
+// DepthFirst panics with a *UniverseError if x is not contained in the
+// universe.
+func DepthFirst(b AbstractInterface, x set.Element, fn func(y set.Element) bool) {
+	checkElement("DepthFirst", b, x)
+
+	successors := successorsOf(b)
+	seen := make(map[set.Element]bool)
+	stack := []set.Element{x}
+
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if seen[v] {
+			continue
+		}
+
+		seen[v] = true
+		if !fn(v) {
+			return
+		}
+
+		// push in reverse, so that successors are visited in order
+		ws := successors(v)
+		for i := len(ws) - 1; i >= 0; i-- {
+			if !seen[ws[i]] {
+				stack = append(stack, ws[i])
+			}
+		}
+	}
+}
+
+// Reachable constructs the set of elements reachable from x in zero or
+// more steps, {y ∈ X | x B* y}, which contains x itself.
+//
+// Reachable panics with a *UniverseError if x is not contained in the
+// universe.
+func Reachable(b AbstractInterface, x set.Element) set.Interface {
+	checkElement("Reachable", b, x)
+
+	s := set.New()
+	breadthFirst(b, x, func(y, _ set.Element, _ int) bool {
+		s.Add(y)
+		return true
+	})
+
+	return s
+}
+
+// --- }}}
+
+// --- Paths {{{
+
+// ShortestPath returns a path of fewest steps from x to y, the
+// elements x = e0, e1, ..., ek = y such that e(i) B e(i+1), or nil if
+// y is not reachable from x. The path from x to itself is [x].
+//
+// ShortestPath panics with a *UniverseError if either element is not
+// contained in the universe.
+func ShortestPath(b AbstractInterface, x, y set.Element) []set.Element {
+	if err := checkPair("ShortestPath", b.Universe(), x, y); err != nil {
+		panic(err)
+	}
+
+	parent := make(map[set.Element]set.Element)
+	found := false
+
+	breadthFirst(b, x, func(v, from set.Element, _ int) bool {
+		parent[v] = from
+		found = v == y
+		return !found
+	})
+
+	if !found {
+		return nil
+	}
+
+	var path []set.Element
+	for v := y; ; v = parent[v] {
+		path = append(path, v)
+		if v == x {
+			break
+		}
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// Distances maps each element reachable from x to the fewest steps in
+// which it is reached, 0 for x itself.
+//
+// Distances panics with a *UniverseError if x is not contained in the
+// universe.
+func Distances(b AbstractInterface, x set.Element) map[set.Element]int {
+	checkElement("Distances", b, x)
+
+	d := make(map[set.Element]int)
+	breadthFirst(b, x, func(y, _ set.Element, depth int) bool {
+		d[y] = depth
+		return true
+	})
+
+	return d
+}
+
+// --- }}}
+
+// --- Components and Cycles {{{
+
+// StronglyConnectedComponents partitions the universe of b into its
+// strongly connected components: the maximal sets in which every
+// element is reachable from every other. The components are listed in
+// topological order, so that if xBy for x and y in distinct
+// components, the component of x is listed first.
+func StronglyConnectedComponents(b AbstractInterface) []set.Interface {
+	g := graphOf(b)
+	comps, _ := g.components()
+
+	cs := make([]set.Interface, len(comps))
+	for c, members := range comps {
+		cs[len(comps)-1-c] = set.With(g.elements(members))
+	}
+
+	return cs
+}
+
+// FindCycle returns the elements of a cycle of b, x0, x1, ..., xk such
+// that x0 B x1, ..., xk B x0, or nil if b is Acyclic. An element related
+// to itself is a cycle of length one.
+func FindCycle(b AbstractInterface) []set.Element {
+	g := graphOf(b)
+
+	if cycle := g.findCycle(); cycle != nil {
+		return g.elements(cycle)
+	}
+
+	return nil
+}
+
+// --- }}}
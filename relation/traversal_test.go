@@ -0,0 +1,198 @@
+package relation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+// implementations returns the relation b as each of the package's
+// representations, and as a predicate backed relation
+func implementations(b relation.Interface) map[string]relation.AbstractInterface {
+	d := relation.NewDense(b.Universe())
+	for _, x := range b.Universe().Elements() {
+		for _, y := range b.Universe().Elements() {
+			if b.ContainsRelation(x, y) {
+				d.AddRelation(x, y)
+			}
+		}
+	}
+
+	return map[string]relation.AbstractInterface{
+		"map":       b,
+		"dense":     d,
+		"predicate": relation.NewFunctionBinaryRelation(b.Universe(), b.ContainsRelation),
+	}
+}
+
+func TestNeighbours(t *testing.T) {
+	b := chain(4)
+	b.AddRelation(3, 1)
+
+	for name, r := range implementations(b) {
+		if s := relation.Successors(r, 1); !set.Equivalent(s, set.WithElements(2)) {
+			t.Errorf("%s: Expected the successors of 1 to be {2}, got %s", name, s)
+		}
+
+		if s := relation.Predecessors(r, 1); !set.Equivalent(s, set.WithElements(0, 3)) {
+			t.Errorf("%s: Expected the predecessors of 1 to be {0, 3}, got %s", name, s)
+		}
+
+		if s := relation.Reachable(r, 2); !set.Equivalent(s, set.WithElements(1, 2, 3)) {
+			t.Errorf("%s: Expected {1, 2, 3} to be reachable from 2, got %s", name, s)
+		}
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, relation.ErrNotInUniverse) {
+			t.Errorf("Expected a panic wrapping ErrNotInUniverse, got %v", err)
+		}
+	}()
+
+	relation.Successors(b, 10)
+}
+
+func TestTraversal(t *testing.T) {
+	b := chain(5)
+	b.AddRelation(0, 2)
+
+	for name, r := range implementations(b) {
+		depths := make(map[set.Element]int)
+		relation.BreadthFirst(r, 0, func(y set.Element, depth int) bool {
+			depths[y] = depth
+			return true
+		})
+
+		expected := map[set.Element]int{0: 0, 1: 1, 2: 1, 3: 2, 4: 3}
+		for e, d := range expected {
+			if depths[e] != d {
+				t.Errorf("%s: Expected %v at depth %d, got %d", name, e, d, depths[e])
+			}
+		}
+
+		var visited []set.Element
+		relation.DepthFirst(r, 0, func(y set.Element) bool {
+			visited = append(visited, y)
+			return y != 3
+		})
+
+		if len(visited) < 3 || visited[0] != 0 || visited[len(visited)-1] != 3 {
+			t.Errorf("%s: Expected the traversal to start at 0 and stop at 3, got %v", name, visited)
+		}
+
+		if path := relation.ShortestPath(r, 0, 4); len(path) != 4 || path[1] != 2 {
+			t.Errorf("%s: Expected the path 0, 2, 3, 4, got %v", name, path)
+		}
+
+		if path := relation.ShortestPath(r, 4, 0); path != nil {
+			t.Errorf("%s: Expected no path from 4 to 0, got %v", name, path)
+		}
+
+		if path := relation.ShortestPath(r, 3, 3); len(path) != 1 {
+			t.Errorf("%s: Expected the path from 3 to itself to be [3], got %v", name, path)
+		}
+
+		if d := relation.Distances(r, 1); len(d) != 4 || d[4] != 3 {
+			t.Errorf("%s: Expected 4 to be 3 steps from 1, got %v", name, d)
+		}
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	b := chain(5)
+	b.AddRelation(3, 1)
+
+	cs := relation.StronglyConnectedComponents(b)
+
+	if len(cs) != 3 {
+		t.Fatalf("Expected 3 components, got %v", cs)
+	}
+
+	if !set.Equivalent(cs[0], set.WithElements(0)) ||
+		!set.Equivalent(cs[1], set.WithElements(1, 2, 3)) ||
+		!set.Equivalent(cs[2], set.WithElements(4)) {
+		t.Errorf("Expected the components {0}, {1, 2, 3}, {4} in order, got %v", cs)
+	}
+
+	cycle := relation.FindCycle(b)
+	if len(cycle) != 3 {
+		t.Fatalf("Expected a cycle of length 3, got %v", cycle)
+	}
+
+	for i, x := range cycle {
+		if !b.ContainsRelation(x, cycle[(i+1)%len(cycle)]) {
+			t.Errorf("Expected %v to be a cycle", cycle)
+		}
+	}
+
+	if cycle := relation.FindCycle(chain(5)); cycle != nil {
+		t.Errorf("Expected a chain to have no cycle, got %v", cycle)
+	}
+}
+
+func TestEachPair(t *testing.T) {
+	b := chain(5)
+
+	for name, r := range implementations(b) {
+		count := 0
+		relation.EachPair(r, func(x, y set.Element) bool {
+			if !b.ContainsRelation(x, y) {
+				t.Errorf("%s: Expected (%v, %v) not to be enumerated", name, x, y)
+			}
+			count++
+			return true
+		})
+
+		if count != 4 {
+			t.Errorf("%s: Expected 4 pairs, got %d", name, count)
+		}
+	}
+
+	// the pairs of a predicate backed relation are tested lazily
+	tested := 0
+	lazy := relation.NewFunctionBinaryRelation(b.Universe(), func(x, y set.Element) bool {
+		tested++
+		return true
+	})
+
+	relation.EachPair(lazy, func(x, y set.Element) bool {
+		return false
+	})
+
+	if tested != 1 {
+		t.Errorf("Expected a single pair to be tested, got %d", tested)
+	}
+}
+
+// plain implements only Interface, as a relation defined outside the
+// package might
+type plain struct{ relation.Interface }
+
+func TestEnumerableOptional(t *testing.T) {
+	b := chain(5)
+	var r relation.Interface = plain{b}
+
+	if _, ok := r.(relation.Enumerable); ok {
+		t.Fatalf("Expected the wrapper not to be Enumerable")
+	}
+
+	for name, r := range map[string]relation.AbstractInterface{"enumerable": b, "plain": r} {
+		if size := relation.Size(r); size != 4 {
+			t.Errorf("%s: Expected 4 pairs, got %d", name, size)
+		}
+
+		if pairs := relation.Pairs(r); len(pairs) != 4 || !b.ContainsRelation(pairs[0].First, pairs[0].Second) {
+			t.Errorf("%s: Expected the 4 pairs of the chain, got %v", name, pairs)
+		}
+
+		if s := relation.Successors(r, 1); !set.Equivalent(s, set.WithElements(2)) {
+			t.Errorf("%s: Expected the successors of 1 to be {2}, got %s", name, s)
+		}
+
+		if s := relation.Predecessors(r, 1); !set.Equivalent(s, set.WithElements(0)) {
+			t.Errorf("%s: Expected the predecessors of 1 to be {0}, got %s", name, s)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package relation
+
+import (
+	"sort"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- Utility Representation {{{
+
+// A Utility assigns a real number to each element of a universe, u:
+// X → ℝ. It represents the weak order B if xBy ⇔ u(x) ≥ u(y).
+type Utility func(set.Element) float64
+
+// ranks computes the indifference classes of the WeakOrder b, best
+// first, as vertices of a snapshot of b.
+func ranks(b AbstractInterface) (*graph, [][]int, error) {
+	g := graphOf(b)
+	if v := g.weakOrderViolation(); v != nil {
+		return nil, nil, v
+	}
+
+	// as b is complete and transitive, x is at least as good as y iff
+	// x is related to at least as many elements as y
+	order := make([]int, len(g.elems))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(g.succ[order[i]]) > len(g.succ[order[j]])
+	})
+
+	var classes [][]int
+	for k, v := range order {
+		if k == 0 || len(g.succ[v]) != len(g.succ[order[k-1]]) {
+			classes = append(classes, nil)
+		}
+
+		classes[len(classes)-1] = append(classes[len(classes)-1], v)
+	}
+
+	return g, classes, nil
+}
+
+// IndifferenceClasses partitions the universe of the WeakOrder b into
+// its indifference classes, best first: x and y are in the same class
+// iff xBy and yBx, and x is in an earlier class than y iff xBy but not
+// yBx. If b is not a WeakOrder, the *Violation is returned as the
+// error.
+func IndifferenceClasses(b AbstractInterface) ([]set.Interface, error) {
+	g, classes, err := ranks(b)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := make([]set.Interface, len(classes))
+	for c, members := range classes {
+		cs[c] = set.With(g.elements(members))
+	}
+
+	return cs, nil
+}
+
+// UtilityOf constructs a Utility which represents the WeakOrder b:
+// each element is assigned the number of indifference classes below
+// its own, so that the worst elements have utility 0. If b is not a
+// WeakOrder, the *Violation is returned as the error.
+//
+// The Utility panics with a *UniverseError if given an element which
+// is not contained in the universe of b.
+func UtilityOf(b AbstractInterface) (Utility, error) {
+	g, classes, err := ranks(b)
+	if err != nil {
+		return nil, err
+	}
+
+	utility := make(map[set.Element]float64, len(g.elems))
+	for c, members := range classes {
+		for _, v := range members {
+			utility[g.elems[v]] = float64(len(classes) - 1 - c)
+		}
+	}
+
+	return func(x set.Element) float64 {
+		u, ok := utility[x]
+		if !ok {
+			panic(&UniverseError{Op: "Utility", Element: x, Position: 1})
+		}
+
+		return u
+	}, nil
+}
+
+// FromUtility constructs the WeakOrder over universe represented by u:
+//
+//	xBy ⇔ u(x) ≥ u(y)
+func FromUtility(universe set.Interface, u Utility) AbstractInterface {
+	return NewFunctionBinaryRelation(universe, func(x, y set.Element) bool {
+		return u(x) >= u(y)
+	})
+}
+
+// --- }}}
+
+// --- Multiple Utilities {{{
+
+// Lexicographic constructs the lexicographic order over universe of
+// the utilities us: x is at least as good as y if they have the same
+// utility under each of us, or if under the first utility which
+// distinguishes them, x has the greater. For example, a dictionary
+// orders words by their first letter, then by their second, and so on.
+//
+// The lexicographic order is a WeakOrder.
+func Lexicographic(universe set.Interface, us ...Utility) AbstractInterface {
+	return NewFunctionBinaryRelation(universe, func(x, y set.Element) bool {
+		for _, u := range us {
+			if ux, uy := u(x), u(y); ux != uy {
+				return ux > uy
+			}
+		}
+
+		return true
+	})
+}
+
+// ParetoDominance constructs the Pareto dominance relation over
+// universe of the utilities us: x is at least as good as y if it is
+// at least as good under every utility,
+//
+//	xBy ⇔ u(x) ≥ u(y) for each u in us
+//
+// Pareto dominance is a Preorder, but need not be Complete: x and y
+// are incomparable if each is better than the other under some
+// utility.
+func ParetoDominance(universe set.Interface, us ...Utility) AbstractInterface {
+	return NewFunctionBinaryRelation(universe, func(x, y set.Element) bool {
+		for _, u := range us {
+			if u(x) < u(y) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// --- }}}
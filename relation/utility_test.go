@@ -0,0 +1,91 @@
+package relation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nlandolfi/set"
+	"github.com/nlandolfi/set/relation"
+)
+
+func TestUtilityOf(t *testing.T) {
+	// order by the number of letters, so that "ab" ~ "cd"
+	words := set.WithElements("a", "ab", "cd", "abc")
+	longer := relation.NewFunctionBinaryRelation(words, func(x, y set.Element) bool {
+		return len(x.(string)) >= len(y.(string))
+	})
+
+	classes, err := relation.IndifferenceClasses(longer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(classes) != 3 ||
+		!set.Equivalent(classes[0], set.WithElements("abc")) ||
+		!set.Equivalent(classes[1], set.WithElements("ab", "cd")) ||
+		!set.Equivalent(classes[2], set.WithElements("a")) {
+		t.Errorf("Expected the classes {abc}, {ab, cd}, {a}, got %v", classes)
+	}
+
+	u, err := relation.UtilityOf(longer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u("a") != 0 || u("ab") != 1 || u("cd") != 1 || u("abc") != 2 {
+		t.Errorf("Expected the utilities 0, 1, 1, 2")
+	}
+
+	if !equal(relation.FromUtility(words, u), longer) {
+		t.Errorf("Expected the utility to represent the order")
+	}
+
+	if _, err := relation.UtilityOf(divides(1, 2, 3)); err == nil {
+		t.Errorf("Expected divisibility, which is not complete, to have no utility")
+	}
+
+	// one snapshot serves the check and the classes
+	var calls int64
+	if _, err := relation.IndifferenceClasses(counting(&calls)); err != nil {
+		t.Fatal(err)
+	} else if n := int64(numbers.Cardinality()); calls != n*n {
+		t.Errorf("Expected IndifferenceClasses to make %d calls, got %d", n*n, calls)
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, relation.ErrNotInUniverse) {
+			t.Errorf("Expected a panic wrapping ErrNotInUniverse, got %v", err)
+		}
+	}()
+
+	u("abcd")
+}
+
+func TestMultipleUtilities(t *testing.T) {
+	type bundle struct{ apples, pears int }
+
+	bundles := set.WithElements(bundle{1, 1}, bundle{2, 0}, bundle{2, 1}, bundle{0, 3})
+
+	apples := func(e set.Element) float64 { return float64(e.(bundle).apples) }
+	pears := func(e set.Element) float64 { return float64(e.(bundle).pears) }
+
+	lex := relation.Lexicographic(bundles, apples, pears)
+
+	if !relation.WeakOrder(lex) || !relation.AntiSymmetric(lex) {
+		t.Errorf("Expected the lexicographic order to be a linear order")
+	}
+
+	if !lex.ContainsRelation(bundle{2, 0}, bundle{1, 1}) || lex.ContainsRelation(bundle{2, 0}, bundle{2, 1}) {
+		t.Errorf("Expected apples to be compared before pears")
+	}
+
+	pareto := relation.ParetoDominance(bundles, apples, pears)
+
+	if !relation.Preorder(pareto) || relation.Complete(pareto) {
+		t.Errorf("Expected Pareto dominance to be a preorder, but not complete")
+	}
+
+	if !pareto.ContainsRelation(bundle{2, 1}, bundle{1, 1}) || pareto.ContainsRelation(bundle{2, 0}, bundle{0, 3}) {
+		t.Errorf("Expected (2, 1) to dominate (1, 1), but (2, 0) not to dominate (0, 3)")
+	}
+}
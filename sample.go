@@ -0,0 +1,229 @@
+package set
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// --- Sampling {{{
+
+// The functions of this section draw from a *rand.Rand supplied by the
+// caller. As the order of Elements is arbitrary, they visit the
+// elements of a set in the order of Sorted, so that a source with the
+// same seed draws the same sample. This costs O(n log n) for a set of
+// n elements, regardless of the size of the sample.
+
+// Pick chooses an element of s uniformly at random. It returns false
+// if s is empty.
+func Pick(rng *rand.Rand, s Interface) (Element, bool) {
+	elements := Sorted(s)
+	if len(elements) == 0 {
+		return nil, false
+	}
+
+	return elements[rng.Intn(len(elements))], true
+}
+
+// Sample chooses k distinct elements of s uniformly at random, in
+// random order: each of the |s|!/(|s|-k)! such lists is equally
+// likely.
+//
+// Sample panics unless 0 ≤ k ≤ |s|.
+func Sample(rng *rand.Rand, s Interface, k int) Elements {
+	elements := Sorted(s)
+	if k < 0 || k > len(elements) {
+		panic("set: Sample: size out of range")
+	}
+
+	// a partial Fisher-Yates shuffle
+	for i := 0; i < k; i++ {
+		j := i + rng.Intn(len(elements)-i)
+		elements[i], elements[j] = elements[j], elements[i]
+	}
+
+	return elements[:k:k]
+}
+
+// SampleWithReplacement chooses k elements of s independently and
+// uniformly at random, so that an element may be chosen more than
+// once.
+//
+// SampleWithReplacement panics if k < 0, or if k > 0 and s is empty.
+func SampleWithReplacement(rng *rand.Rand, s Interface, k int) Elements {
+	elements := Sorted(s)
+	if k < 0 || (k > 0 && len(elements) == 0) {
+		panic("set: SampleWithReplacement: size out of range")
+	}
+
+	sample := make(Elements, k)
+	for i := range sample {
+		sample[i] = elements[rng.Intn(len(elements))]
+	}
+
+	return sample
+}
+
+// Shuffle lists the elements of s in random order, each of the |s|!
+// orders being equally likely.
+func Shuffle(rng *rand.Rand, s Interface) Elements {
+	elements := Sorted(s)
+
+	rng.Shuffle(len(elements), func(i, j int) {
+		elements[i], elements[j] = elements[j], elements[i]
+	})
+
+	return elements
+}
+
+// --- }}}
+
+// --- Weighted Sampling {{{
+
+// A Weight assigns a non-negative weight to each element of a set, in
+// proportion to which it is chosen by the weighted samplers.
+type Weight func(Element) float64
+
+// weighted lists the elements of s with positive weight, and their
+// weights, or panics if some weight is negative or not a number.
+func weighted(op string, s Interface, weight Weight) (Elements, []float64) {
+	var (
+		elements Elements
+		weights  []float64
+	)
+
+	for _, e := range Sorted(s) {
+		w := weight(e)
+
+		if w < 0 || math.IsNaN(w) {
+			panic("set: " + op + ": invalid weight")
+		}
+
+		if w > 0 {
+			elements = append(elements, e)
+			weights = append(weights, w)
+		}
+	}
+
+	return elements, weights
+}
+
+// PickWeighted chooses an element of s at random, each with
+// probability proportional to its weight. Elements of weight 0 are
+// never chosen. It returns false if no element has positive weight.
+//
+// PickWeighted panics if some weight is negative or not a number.
+func PickWeighted(rng *rand.Rand, s Interface, weight Weight) (Element, bool) {
+	elements, weights := weighted("PickWeighted", s, weight)
+	if len(elements) == 0 {
+		return nil, false
+	}
+
+	// cumulative[i] is the total weight of elements[:i+1]
+	cumulative := make([]float64, len(weights))
+	total := 0.0
+	for i, w := range weights {
+		total += w
+		cumulative[i] = total
+	}
+
+	target := rng.Float64() * total
+	i := sort.Search(len(cumulative), func(i int) bool {
+		return cumulative[i] > target
+	})
+
+	// guard against rounding at the top of the range
+	if i == len(elements) {
+		i--
+	}
+
+	return elements[i], true
+}
+
+// SampleWeighted chooses k distinct elements of s at random, in the
+// order drawn: each draw chooses one of the remaining elements with
+// probability proportional to its weight. Elements of weight 0 are
+// never chosen, so fewer than k are returned if fewer than k elements
+// have positive weight.
+//
+// SampleWeighted panics if k < 0, or some weight is negative or not a
+// number.
+//
+// Source: Efraimidis and Spirakis, "Weighted random sampling with a
+// reservoir", 2006: each element is keyed by u^(1/w), for u uniform
+// on [0, 1), and the k largest keys are taken.
+func SampleWeighted(rng *rand.Rand, s Interface, k int, weight Weight) Elements {
+	if k < 0 {
+		panic("set: SampleWeighted: size out of range")
+	}
+
+	elements, weights := weighted("SampleWeighted", s, weight)
+
+	// compare logarithms of the keys, log(u)/w, which do not underflow
+	keys := make([]float64, len(elements))
+	order := make([]int, len(elements))
+	for i, w := range weights {
+		keys[i] = math.Log(rng.Float64()) / w
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return keys[order[i]] > keys[order[j]]
+	})
+
+	if k > len(order) {
+		k = len(order)
+	}
+
+	sample := make(Elements, k)
+	for i := range sample {
+		sample[i] = elements[order[i]]
+	}
+
+	return sample
+}
+
+// --- }}}
+
+// --- Reservoir Sampling {{{
+
+// Reservoir chooses k of the elements received from elements uniformly
+// at random, consuming the channel until it is closed, in a single
+// pass and O(k) memory. If fewer than k elements are received, all are
+// returned. The sample is in no particular order.
+//
+// Reservoir is suited to streams too large to hold, such as the Iter
+// of a large set. The sample is reproducible given the same source and
+// the same order of elements.
+//
+// Reservoir panics if k < 0.
+//
+// Source: Vitter's Algorithm R,
+//
+//	https://en.wikipedia.org/wiki/Reservoir_sampling
+func Reservoir(rng *rand.Rand, elements <-chan Element, k int) Elements {
+	if k < 0 {
+		panic("set: Reservoir: size out of range")
+	}
+
+	reservoir := make(Elements, 0, k)
+	seen := 0
+
+	for e := range elements {
+		seen++
+
+		if len(reservoir) < k {
+			reservoir = append(reservoir, e)
+			continue
+		}
+
+		// keep e with probability k/seen, in place of a random member
+		if j := rng.Intn(seen); j < k {
+			reservoir[j] = e
+		}
+	}
+
+	return reservoir
+}
+
+// --- }}}
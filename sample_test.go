@@ -0,0 +1,198 @@
+package set_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/nlandolfi/set"
+)
+
+// --- TestPick {{{
+
+func TestPick(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(1))
+
+	if _, ok := set.Pick(rng, set.New()); ok {
+		t.Fatalf("Pick of the empty set should fail")
+	}
+
+	s := set.WithElements("a", "b", "c")
+	counts := make(map[set.Element]int)
+
+	for i := 0; i < 3000; i++ {
+		e, ok := set.Pick(rng, s)
+		if !ok || !s.Contains(e) {
+			t.Fatalf("Pick of %s should be a member, got %v", s, e)
+		}
+		counts[e]++
+	}
+
+	for _, e := range s.Elements() {
+		if counts[e] < 800 || counts[e] > 1200 {
+			t.Fatalf("Pick should be uniform, got counts %v", counts)
+		}
+	}
+
+	// a source with the same seed picks the same element
+	x, _ := set.Pick(rand.New(rand.NewSource(2)), s)
+	y, _ := set.Pick(rand.New(rand.NewSource(2)), set.Clone(s))
+	if x != y {
+		t.Fatalf("Pick with the same seed should agree, got %v and %v", x, y)
+	}
+}
+
+// --- }}}
+
+// --- TestSample {{{
+
+func TestSample(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(3))
+	s := set.WithElements(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+
+	for k := 0; k <= 10; k++ {
+		sample := set.Sample(rng, s, k)
+		if len(sample) != k {
+			t.Fatalf("Sample of size %d has %d elements", k, len(sample))
+		}
+
+		if distinct := set.With(sample); distinct.Cardinality() != uint(k) || !set.IsSubset(distinct, s) {
+			t.Fatalf("Sample should be %d distinct members, got %v", k, sample)
+		}
+	}
+
+	if sample := set.SampleWithReplacement(rng, s, 100); len(sample) != 100 || !set.IsSubset(set.With(sample), s) {
+		t.Fatalf("SampleWithReplacement should be 100 members, got %v", sample)
+	}
+
+	if shuffled := set.Shuffle(rng, s); len(shuffled) != 10 || !set.Equivalent(set.With(shuffled), s) {
+		t.Fatalf("Shuffle should list each member once, got %v", shuffled)
+	}
+
+	a := set.Shuffle(rand.New(rand.NewSource(4)), s)
+	b := set.Shuffle(rand.New(rand.NewSource(4)), set.Clone(s))
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("Shuffle with the same seed should agree, got %v and %v", a, b)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Sample larger than the set should panic")
+		}
+	}()
+
+	set.Sample(rng, s, 11)
+}
+
+// --- }}}
+
+// --- TestWeighted {{{
+
+func TestWeighted(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(5))
+	s := set.WithElements("heavy", "light", "never")
+
+	weight := func(e set.Element) float64 {
+		switch e {
+		case "heavy":
+			return 3
+		case "light":
+			return 1
+		}
+		return 0
+	}
+
+	counts := make(map[set.Element]int)
+	for i := 0; i < 4000; i++ {
+		e, ok := set.PickWeighted(rng, s, weight)
+		if !ok {
+			t.Fatalf("PickWeighted should succeed")
+		}
+		counts[e]++
+	}
+
+	if counts["never"] != 0 || counts["heavy"] < 2700 || counts["heavy"] > 3300 {
+		t.Fatalf("PickWeighted should be proportional to weight, got counts %v", counts)
+	}
+
+	zero := func(set.Element) float64 { return 0 }
+	if _, ok := set.PickWeighted(rng, s, zero); ok {
+		t.Fatalf("PickWeighted with no positive weight should fail")
+	}
+
+	if sample := set.SampleWeighted(rng, s, 3, weight); len(sample) != 2 || set.With(sample).Contains("never") {
+		t.Fatalf("SampleWeighted should omit elements of weight 0, got %v", sample)
+	}
+
+	first := 0
+	for i := 0; i < 4000; i++ {
+		if set.SampleWeighted(rng, s, 1, weight)[0] == "heavy" {
+			first++
+		}
+	}
+
+	if first < 2700 || first > 3300 {
+		t.Fatalf("SampleWeighted should draw in proportion to weight, drew heavy first %d times", first)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("A negative weight should panic")
+		}
+	}()
+
+	set.PickWeighted(rng, s, func(set.Element) float64 { return -1 })
+}
+
+// --- }}}
+
+// --- TestReservoir {{{
+
+func TestReservoir(t *testing.T) {
+	t.Parallel()
+
+	rng := rand.New(rand.NewSource(6))
+
+	stream := func(n int) <-chan set.Element {
+		c := make(chan set.Element)
+		go func() {
+			for i := 0; i < n; i++ {
+				c <- i
+			}
+			close(c)
+		}()
+		return c
+	}
+
+	if sample := set.Reservoir(rng, stream(3), 5); len(sample) != 3 {
+		t.Fatalf("Reservoir of a short stream should keep everything, got %v", sample)
+	}
+
+	counts := make([]int, 10)
+	for i := 0; i < 2000; i++ {
+		sample := set.Reservoir(rng, stream(10), 3)
+		if distinct := set.With(sample); len(sample) != 3 || distinct.Cardinality() != 3 {
+			t.Fatalf("Reservoir should keep 3 distinct elements, got %v", sample)
+		}
+
+		for _, e := range sample {
+			counts[e.(int)]++
+		}
+	}
+
+	// each element is kept with probability 3/10
+	for e, c := range counts {
+		if c < 450 || c > 750 {
+			t.Fatalf("Reservoir should be uniform, kept %d %d times", e, c)
+		}
+	}
+}
+
+// --- }}}
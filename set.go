@@ -2,6 +2,7 @@ package set
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -300,6 +301,31 @@ func String(s Interface) string {
 	return fmt.Sprintf("{%s}", strings.Join(elementStrings, ", "))
 }
 
+// Sorted retrieves the elements of s ordered by their string
+// representation, fmt.Sprint, and then by the name of their type, so
+// that iteration is reproducible; 1 precedes "1", as "int" < "string".
+// The order of elements alike in both, such as distinct pointers to
+// equal values, is unspecified.
+func Sorted(s Interface) Elements {
+	elements := s.Elements()
+
+	representations := make(map[Element][2]string, len(elements))
+	for _, e := range elements {
+		representations[e] = [2]string{fmt.Sprint(e), fmt.Sprintf("%T", e)}
+	}
+
+	sort.SliceStable(elements, func(i, j int) bool {
+		ri, rj := representations[elements[i]], representations[elements[j]]
+		if ri[0] != rj[0] {
+			return ri[0] < rj[0]
+		}
+
+		return ri[1] < rj[1]
+	})
+
+	return elements
+}
+
 // Clone creates a carbon copy of s1.
 func Clone(s1 Interface) Interface {
 	return With(s1.Elements())
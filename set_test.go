@@ -393,3 +393,33 @@ func TestPowerSet(t *testing.T) {
 }
 
 // --- }}}
+
+// --- TestSorted {{{
+
+func TestSorted(t *testing.T) {
+	t.Parallel()
+
+	s := set.WithElements(10, 2, "b", "a", 1)
+	sorted := set.Sorted(s)
+
+	expected := []set.Element{1, 10, 2, "a", "b"}
+	if len(sorted) != len(expected) {
+		t.Fatalf("Sorted(%s) should have %d elements, has %d", s, len(expected), len(sorted))
+	}
+
+	for i := range expected {
+		if sorted[i] != expected[i] {
+			t.Fatalf("Sorted(%s) should be %v, is %v", s, expected, sorted)
+		}
+	}
+
+	// alike under fmt.Sprint, so ordered by type
+	for n := 0; n < 20; n++ {
+		alike := set.WithElements("1", int64(1), 1)
+		if sorted := set.Sorted(alike); sorted[0] != 1 || sorted[1] != int64(1) || sorted[2] != "1" {
+			t.Fatalf("Sorted(%s) should order by type, int, int64 then string, is %#v", alike, sorted)
+		}
+	}
+}
+
+// --- }}}